@@ -3,17 +3,27 @@ package cmd
 import (
 	goflag "flag"
 	"log"
+	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/peterbourgon/ff/v3"
 
+	"github.com/metatube-community/metatube-sdk-go/common/number"
 	"github.com/metatube-community/metatube-sdk-go/database"
 	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/imageutil/badge"
 	"github.com/metatube-community/metatube-sdk-go/internal/envconfig"
 	"github.com/metatube-community/metatube-sdk-go/route"
 	"github.com/metatube-community/metatube-sdk-go/route/auth"
+	"github.com/metatube-community/metatube-sdk-go/translate"
+)
+
+const (
+	translateCacheCapacity = 100_000
+	translateCacheTTL      = 7 * 24 * time.Hour
 )
 
 var Config = &struct {
@@ -23,6 +33,21 @@ var Config = &struct {
 	Token string
 	DSN   string
 
+	// image config
+	ImageSignKey        string
+	ImageRejectUnsigned bool
+	ImageHostAllowlist  bool
+	BadgeFont           string
+
+	// number config
+	NumberRules string
+
+	// translate config
+	TranslateCache            string
+	TranslateGlossary         string
+	TranslateProfiles         string
+	TranslateAllowCredentials bool
+
 	// engine config
 	RequestTimeout time.Duration
 
@@ -48,10 +73,19 @@ func init() {
 	flag.StringVar(&Config.Port, "port", "8080", "Port number of server")
 	flag.StringVar(&Config.Token, "token", "", "Token to access server")
 	flag.StringVar(&Config.DSN, "dsn", "", "Database Service Name")
+	flag.StringVar(&Config.ImageSignKey, "image-sign-key", "", "Secret key to sign image URLs")
+	flag.BoolVar(&Config.ImageRejectUnsigned, "image-reject-unsigned", false, "Reject unsigned image requests with url query")
+	flag.BoolVar(&Config.ImageHostAllowlist, "image-host-allowlist", false, "Restrict image url query to provider hosts")
+	flag.StringVar(&Config.BadgeFont, "badge-font", "", "Font file for text badges, required for non-Latin texts, e.g., CJK")
+	flag.StringVar(&Config.NumberRules, "number-rules", "", "User rules file of number extraction in JSON")
+	flag.StringVar(&Config.TranslateCache, "translate-cache", "", "Translation cache backend: memory or db")
+	flag.StringVar(&Config.TranslateGlossary, "translate-glossary", "", "Glossary file of translation terms in JSON")
+	flag.StringVar(&Config.TranslateProfiles, "translate-profiles", "", "Translator profiles file in JSON")
+	flag.BoolVar(&Config.TranslateAllowCredentials, "translate-allow-credentials", false, "Allow translator credentials in url query")
 	flag.DurationVar(&Config.RequestTimeout, "request-timeout", engine.DefaultRequestTimeout, "Timeout per request")
 	flag.IntVar(&Config.DBMaxIdleConns, "db-max-idle-conns", 0, "Database max idle connections")
 	flag.IntVar(&Config.DBMaxOpenConns, "db-max-open-conns", 0, "Database max open connections")
-	flag.BoolVar(&Config.DBAutoMigrate, "db-auto-migrate", false, "Database auto migration")
+	flag.BoolVar(&Config.DBAutoMigrate, "db-auto-migrate", false, "Database auto migration, required once to create new tables after upgrades")
 	flag.BoolVar(&Config.DBPreparedStmt, "db-prepared-stmt", false, "Database prepared statement")
 	flag.BoolVar(&Config.VersionFlag, "version", false, "Show version")
 	ff.Parse(flag, os.Args[1:], ff.WithEnvVars())
@@ -92,6 +126,24 @@ func Router(names ...string) *gin.Engine {
 		opts = append(opts, engine.WithMovieProviderConfig(provider, config))
 	}
 
+	// merge user rules of number extraction
+	if Config.NumberRules != "" {
+		f, err := os.Open(Config.NumberRules)
+		if err != nil {
+			log.Fatal(err)
+		}
+		rules, err := number.LoadRules(f)
+		_ = f.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+		rs, err := number.DefaultRules().Merge(rules...)
+		if err != nil {
+			log.Fatal(err)
+		}
+		number.SetRules(rs)
+	}
+
 	app := engine.New(db, opts...)
 
 	// always enable auto migrate for sqlite DB
@@ -102,10 +154,161 @@ func Router(names ...string) *gin.Engine {
 		log.Fatal(err)
 	}
 
+	if Config.BadgeFont != "" {
+		data, err := os.ReadFile(Config.BadgeFont)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err = badge.SetFont(data); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	var token auth.Validator
 	if Config.Token != "" {
 		token = auth.Token(Config.Token)
 	}
 
-	return route.New(app, token)
+	// router options
+	var routeOpts []route.Option
+
+	if Config.ImageSignKey != "" {
+		routeOpts = append(routeOpts,
+			route.WithImageSigner(auth.NewSigner(Config.ImageSignKey)),
+			route.WithRejectUnsignedImageURL(Config.ImageRejectUnsigned))
+	} else if Config.ImageRejectUnsigned {
+		log.Fatal("image-reject-unsigned requires image-sign-key")
+	}
+
+	if Config.ImageHostAllowlist {
+		routeOpts = append(routeOpts, route.WithImageHostAllowlist(true))
+	}
+
+	var translateCache *translate.Cache
+	switch Config.TranslateCache {
+	case "":
+	case "memory":
+		translateCache = translate.NewCache(
+			translate.NewMemoryCacheStore(translateCacheCapacity, translateCacheTTL))
+	case "db":
+		translateCache = translate.NewCache(app.TranslationCacheStore())
+	default:
+		log.Fatalf("unknown translate cache: %s", Config.TranslateCache)
+	}
+	if translateCache != nil {
+		routeOpts = append(routeOpts, route.WithTranslateCache(translateCache))
+	}
+
+	glossary := translate.NewGlossary()
+	// the glossary_terms table is created by the DB auto migration, start
+	// with an empty glossary instead if the table is not migrated yet.
+	if err = glossary.LoadStore(app.GlossaryStore()); err != nil {
+		log.Printf("load glossary store: %v, glossary terms will not persist, "+
+			"restart with -db-auto-migrate to create the glossary table", err)
+	}
+	if Config.TranslateGlossary != "" {
+		f, err := os.Open(Config.TranslateGlossary)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = glossary.Load(f)
+		_ = f.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	routeOpts = append(routeOpts, route.WithTranslateGlossary(glossary))
+
+	if Config.TranslateAllowCredentials {
+		routeOpts = append(routeOpts, route.WithTranslateRawCredentials(true))
+	}
+
+	// server-side translator profiles
+	profiles := make(map[string]*translate.Profile)
+	for name, config := range envconfig.TranslateProfileConfigs.Iterator() {
+		profile := &translate.Profile{Options: make(map[string]string)}
+		for key, value := range config.Iterator() {
+			if strings.EqualFold(key, "engine") {
+				profile.Engine = value
+				continue
+			}
+			// e.g., DEEPL_API_KEY -> deepl-api-key
+			profile.Options[strings.ReplaceAll(strings.ToLower(key), "_", "-")] = value
+		}
+		profiles[strings.ToLower(name)] = profile
+	}
+	if Config.TranslateProfiles != "" {
+		f, err := os.Open(Config.TranslateProfiles)
+		if err != nil {
+			log.Fatal(err)
+		}
+		loaded, err := translate.LoadProfiles(f)
+		_ = f.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+		for name, profile := range loaded {
+			profiles[strings.ToLower(name)] = profile
+		}
+	}
+	// named translators of profiles and chains.
+	named := make(map[string]translate.Translator)
+	for name, profile := range profiles {
+		translator := profile.New()
+		if err, ok := translator.(error); ok {
+			log.Fatalf("translate profile %s: %v", name, err)
+		}
+		named[name] = translateCache.Wrap(profile.Engine, translator)
+		routeOpts = append(routeOpts, route.WithTranslateProfile(name, named[name]))
+	}
+
+	// global engine options, e.g., MT_TRANSLATE__DEEPL_API_KEY.
+	values := url.Values{}
+	for key, value := range envconfig.TranslateConfig.Iterator() {
+		// e.g., DEEPL_API_KEY -> deepl-api-key
+		values.Set(strings.ReplaceAll(strings.ToLower(key), "_", "-"), value)
+	}
+	newTranslator := func(name string) translate.Translator {
+		translator := translate.NewWithValues(name, values)
+		if err, ok := translator.(error); ok {
+			log.Fatalf("translate engine %s: %v", name, err)
+		}
+		return translateCache.Wrap(name, translator)
+	}
+
+	// server-side translator chains
+	var chainOpts []translate.ChainOption
+	if timeout, err := envconfig.TranslateConfig.GetDuration("chain_timeout"); err == nil {
+		chainOpts = append(chainOpts, translate.WithChainTimeout(timeout))
+	}
+	for name, engines := range envconfig.TranslateChainConfigs.Iterator() {
+		chain := translate.NewChain(chainOpts...)
+		for _, engine := range strings.Split(engines, ",") {
+			engine = strings.ToLower(strings.TrimSpace(engine))
+			// profiles take precedence over engines.
+			if translator, ok := named[engine]; ok {
+				chain.Add(engine, translator)
+			} else {
+				chain.Add(engine, newTranslator(engine))
+			}
+		}
+		named[strings.ToLower(name)] = chain
+		routeOpts = append(routeOpts, route.WithTranslateChain(name, chain))
+	}
+
+	// server-side metadata translation
+	if name, err := envconfig.TranslateConfig.GetString("engine"); err == nil {
+		translator, ok := named[strings.ToLower(name)]
+		if !ok {
+			translator = newTranslator(name)
+		}
+		var fields []string
+		if v, err := envconfig.TranslateConfig.GetString("fields"); err == nil {
+			fields = strings.Split(v, ",")
+		}
+		routeOpts = append(routeOpts, route.WithMetadataTranslator(
+			translate.NewMetadataTranslator(glossary.Wrap(translator), glossary, fields...)))
+	}
+
+	return route.New(app, token, routeOpts...)
 }
@@ -0,0 +1,66 @@
+// Command number prints the number of each filename, or of each line
+// of the standard input if no filename is given, along with the rules
+// that transformed the filename step by step.
+//
+// Usage: number [-rules file] [filename ...]
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+)
+
+var rulesFile = flag.String("rules", "", "User rules file in JSON, merged with the default rules")
+
+func loadRules(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	rules, err := number.LoadRules(f)
+	if err != nil {
+		return err
+	}
+	rs, err := number.DefaultRules().Merge(rules...)
+	if err != nil {
+		return err
+	}
+	number.SetRules(rs)
+	return nil
+}
+
+func explain(filename string) {
+	num, steps := number.Explain(filename)
+	fmt.Printf("%s => %s\n", filename, num)
+	for _, step := range steps {
+		fmt.Printf("  %-20s %q -> %q\n", step.Rule, step.Before, step.After)
+	}
+}
+
+func main() {
+	flag.Parse()
+	if *rulesFile != "" {
+		if err := loadRules(*rulesFile); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if flag.NArg() > 0 {
+		for _, filename := range flag.Args() {
+			explain(filename)
+		}
+		return
+	}
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		explain(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -1,6 +1,7 @@
 package fetch
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/metatube-community/metatube-sdk-go/common/random"
@@ -24,6 +25,12 @@ func WithRequest(fn func(req *http.Request)) Option {
 	return func(c *Context) { fn(c.req) }
 }
 
+// WithContext cancels the request along with ctx, including reading
+// the response body.
+func WithContext(ctx context.Context) Option {
+	return func(c *Context) { c.req = c.req.WithContext(ctx) }
+}
+
 func WithHeader(key, value string) Option {
 	return WithRequest(func(req *http.Request) {
 		req.Header.Set(key, value)
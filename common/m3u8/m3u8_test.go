@@ -2,6 +2,8 @@ package m3u8
 
 import (
 	"bytes"
+	"net/url"
+	"strings"
 	"testing"
 	"unsafe"
 
@@ -57,3 +59,33 @@ func TestParseBestMediaURI(t *testing.T) {
 	require.Equal(t, "", url)
 	require.Equal(t, m3u8.MEDIA, typ)
 }
+
+func TestRewriteURIs(t *testing.T) {
+	const playlist = `#EXTM3U
+#EXT-X-TARGETDURATION:10
+#EXT-X-KEY:METHOD=AES-128,URI="key.bin",IV=0x1
+#EXTINF:10,
+0640/06400.ts
+#EXTINF:10,
+https://cdn.example.com/0640/0640533.ts
+#EXT-X-ENDLIST
+`
+	const want = `#EXTM3U
+#EXT-X-TARGETDURATION:10
+#EXT-X-KEY:METHOD=AES-128,URI="/proxy?uri=https%3A%2F%2Fexample.com%2Fvod%2Fkey.bin",IV=0x1
+#EXTINF:10,
+/proxy?uri=https%3A%2F%2Fexample.com%2Fvod%2F0640%2F06400.ts
+#EXTINF:10,
+/proxy?uri=https%3A%2F%2Fcdn.example.com%2F0640%2F0640533.ts
+#EXT-X-ENDLIST
+`
+	base, err := url.Parse("https://example.com/vod/index.m3u8")
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	err = RewriteURIs(buf, strings.NewReader(playlist), base, func(u *url.URL) string {
+		return "/proxy?uri=" + url.QueryEscape(u.String())
+	})
+	require.NoError(t, err)
+	require.Equal(t, want, buf.String())
+}
@@ -0,0 +1,48 @@
+package m3u8
+
+import (
+	"bufio"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var uriAttrRe = regexp.MustCompile(`URI="([^"]*)"`)
+
+// RewriteURIs rewrites all URIs of the playlist read from r, including
+// the segment/variant lines and URI attributes of tags, e.g., EXT-X-KEY.
+// Relative URIs are resolved against base before calling fn.
+func RewriteURIs(w io.Writer, r io.Reader, base *url.URL, fn func(*url.URL) string) error {
+	resolve := func(ref string) string {
+		u, err := base.Parse(ref)
+		if err != nil {
+			return ref // as is
+		}
+		return fn(u)
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	bw := bufio.NewWriter(w)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		switch trimmed := strings.TrimSpace(line); {
+		case trimmed == "":
+		case strings.HasPrefix(trimmed, "#"):
+			line = uriAttrRe.ReplaceAllStringFunc(line, func(attr string) string {
+				ref := uriAttrRe.FindStringSubmatch(attr)[1]
+				return `URI="` + resolve(ref) + `"`
+			})
+		default:
+			line = resolve(trimmed)
+		}
+		if _, err := bw.WriteString(line + "\n"); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,242 @@
+package number
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Info is the information parsed from a filename.
+type Info struct {
+	Filename string `json:"filename"`
+	// Number is the number trimmed from the filename.
+	Number string `json:"number"`
+	// Part is the 1-based index of multi-part files, or 0 if not.
+	Part       int    `json:"part,omitempty"`
+	Subtitle   bool   `json:"subtitle"`
+	Uncensored bool   `json:"uncensored"`
+	Leak       bool   `json:"leak"`
+	Resolution string `json:"resolution,omitempty"`
+	Studio     string `json:"studio,omitempty"`
+}
+
+var (
+	separatorRe = regexp.MustCompile(`[-_.,\s\[\]()【】@]+`)
+	extensionRe = regexp.MustCompile(`\.[^./]{0,5}$`)
+
+	partRe       = regexp.MustCompile(`(?i)^(?:cd|part|pt|disc)(\d{1,2})$`)
+	resolutionRe = regexp.MustCompile(`(?i)^(?:x?(\d{3,4})[pix]|([248])ks?)`)
+)
+
+// suffix tokens right after the number.
+var (
+	subtitleSuffixes   = []string{"c", "ch", "uc", "sub"}
+	uncensoredSuffixes = []string{"u", "uc"}
+	partSuffixes       = map[string]int{"a": 1, "b": 2, "d": 4}
+)
+
+// keyword tokens anywhere in the filename.
+var (
+	subtitleKeywords   = []string{"字幕", "中字", "chs", "cht"}
+	uncensoredKeywords = []string{"uncensored", "無修正", "无修正", "無碼", "无码"}
+	leakKeywords       = []string{"leak", "leaked", "流出"}
+)
+
+// makerStudios are uncensored studios detected by the maker names in
+// filenames, the number itself is excluded, e.g., PACO-345 is a regular
+// number.
+var makerStudios = []struct {
+	re     *regexp.Regexp
+	studio string
+}{
+	{regexp.MustCompile(`(?i)(?:^|[^a-z\d])carib(?:b?ean)?(?:com)?pr(?:[^a-z\d]|$)`), "CaribbeancomPR"},
+	{regexp.MustCompile(`(?i)(?:^|[^a-z\d])carib(?:b?ean)?(?:com)?(?:[^a-z\d]|$)`), "Caribbeancom"},
+	{regexp.MustCompile(`(?i)(?:^|[^a-z\d])(?:1pon(?:do)?|pondo)(?:[^a-z\d]|$)`), "1Pondo"},
+	{regexp.MustCompile(`(?i)(?:^|[^a-z\d])10mu(?:sume)?(?:[^a-z\d]|$)`), "10musume"},
+	{regexp.MustCompile(`(?i)(?:^|[^a-z\d])paco(?:paco)?(?:mama)?(?:[^a-z\d]|$)`), "Pacopacomama"},
+	{regexp.MustCompile(`(?i)(?:^|[^a-z\d])mura(?:mura)?(?:[^a-z\d]|$)`), "Muramura"},
+	{regexp.MustCompile(`(?i)(?:^|[^a-z\d])tokyo[-_\s]?hot(?:[^a-z\d]|$)`), "Tokyo-Hot"},
+}
+
+// numberStudios are studios detected by the numbers.
+var numberStudios = []struct {
+	re     *regexp.Regexp
+	studio string
+}{
+	{regexp.MustCompile(`^(?i)FC2([-_]?PPV)?[-_]?\d+$`), "FC2"},
+	{regexp.MustCompile(`^(?i)heyzo[-_]`), "HEYZO"},
+	{regexp.MustCompile(`^(?i)heydouga[-_]`), "Heydouga"},
+	{regexp.MustCompile(`^(?i)xxx-av[-_]`), "XXX-AV"},
+	{regexp.MustCompile(`^(?i)kin8[-_]`), "Kin8tengoku"},
+	{regexp.MustCompile(`^(?i)h4610[-_]`), "H4610"},
+	{regexp.MustCompile(`^(?i)h0930[-_]`), "H0930"},
+	{regexp.MustCompile(`^(?i)c0930[-_]`), "C0930"},
+	{regexp.MustCompile(`^(?i)1000giri[-_]`), "1000giri"},
+	{regexp.MustCompile(`^(?i)[nk]\d{4}$`), "Tokyo-Hot"},
+	{regexp.MustCompile(`^(?i)gcolle[-_]?`), "Gcolle"},
+	{regexp.MustCompile(`^(?i)pcolle[-_]?`), "Pcolle"},
+	{regexp.MustCompile(`^(?i)getchu[-_]?`), "Getchu"},
+	{regexp.MustCompile(`^(?i)gyutto[-_]?`), "Gyutto"},
+	{regexp.MustCompile(`^(?i)mywife[-_]?`), "Mywife"},
+}
+
+// Parse parses the number, the part index, the subtitle, uncensored and
+// leak flags, the resolution and the studio from the filename.
+func Parse(filename string) *Info {
+	info := &Info{
+		Filename: filename,
+		Number:   Trim(filename),
+	}
+	name := extensionRe.ReplaceAllString(filename, "")
+	before, after := splitByNumber(name, info.Number)
+
+	// suffix tokens right after the number, until the first unknown one.
+	for _, token := range tokenize(after) {
+		lower := strings.ToLower(token)
+		known := false
+		if contains(subtitleSuffixes, lower) {
+			info.Subtitle, known = true, true
+		}
+		if contains(uncensoredSuffixes, lower) {
+			info.Uncensored, known = true, true
+		}
+		if part, ok := parsePart(lower); ok {
+			if info.Part == 0 {
+				info.Part = part
+			}
+			known = true
+		}
+		if !known && !isKeyword(lower) {
+			break
+		}
+	}
+
+	// keywords anywhere but the number.
+	for _, token := range tokenize(before + " " + after) {
+		lower := strings.ToLower(token)
+		if containsAny(lower, subtitleKeywords) {
+			info.Subtitle = true
+		}
+		if containsAny(lower, uncensoredKeywords) {
+			info.Uncensored = true
+		}
+		if containsAny(lower, leakKeywords) {
+			info.Leak = true
+		}
+		if resolution := parseResolution(lower); resolution != "" && info.Resolution == "" {
+			info.Resolution = resolution
+		}
+	}
+
+	// maker studios are all uncensored.
+	for _, s := range makerStudios {
+		if s.re.MatchString(before + " " + after) {
+			info.Studio, info.Uncensored = s.studio, true
+			break
+		}
+	}
+	if info.Studio == "" {
+		for _, s := range numberStudios {
+			if s.re.MatchString(info.Number) {
+				info.Studio = s.studio
+				break
+			}
+		}
+	}
+	if IsUncensored(info.Number) {
+		info.Uncensored = true
+	}
+	return info
+}
+
+// splitByNumber returns the texts before and after the number in name,
+// numbers normalized by rules are located by their last segments.
+func splitByNumber(name, number string) (string, string) {
+	if number == "" {
+		return name, ""
+	}
+	lower := strings.ToLower(name)
+	for _, s := range []string{
+		number,
+		number[strings.LastIndexAny(number, "-_")+1:],
+	} {
+		if i := strings.LastIndex(lower, strings.ToLower(s)); i >= 0 {
+			return name[:i], name[i+len(s):]
+		}
+	}
+	return name, ""
+}
+
+func tokenize(s string) []string {
+	var tokens []string
+	for _, token := range separatorRe.Split(s, -1) {
+		if token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+func parsePart(s string) (int, bool) {
+	if ss := partRe.FindStringSubmatch(s); len(ss) > 1 {
+		part, _ := strconv.Atoi(ss[1])
+		return part, part > 0
+	}
+	if part, ok := partSuffixes[s]; ok {
+		return part, true
+	}
+	if len(s) == 1 && s[0] >= '1' && s[0] <= '9' {
+		return int(s[0] - '0'), true
+	}
+	return 0, false
+}
+
+func parseResolution(s string) string {
+	if s == "fhd" {
+		return "1080p"
+	}
+	ss := resolutionRe.FindStringSubmatch(s)
+	switch {
+	case len(ss) == 0:
+		return ""
+	case ss[2] != "":
+		return ss[2] + "K"
+	}
+	switch height, _ := strconv.Atoi(ss[1]); {
+	case height >= 4320:
+		return "8K"
+	case height >= 2160:
+		return "4K"
+	case height >= 360:
+		return strconv.Itoa(height) + "p"
+	}
+	return ""
+}
+
+// isKeyword reports whether s is a tag that may follow the number.
+func isKeyword(s string) bool {
+	return parseResolution(s) != "" || strings.HasPrefix(s, "hhb") ||
+		containsAny(s, subtitleKeywords) ||
+		containsAny(s, uncensoredKeywords) ||
+		containsAny(s, leakKeywords) ||
+		strings.HasSuffix(s, "fps") ||
+		contains([]string{"hd", "sd", "whole", "dvd", "h264", "h265", "x264", "x265"}, s)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(s string, list []string) bool {
+	for _, v := range list {
+		if strings.Contains(s, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package number
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	for _, unit := range []struct {
+		orig string
+		want Info
+	}{
+		{"ABP-030.mp4", Info{Number: "ABP-030"}},
+		{"ABP-030-C.mp4", Info{Number: "ABP-030", Subtitle: true}},
+		{"ABP-030C", Info{Number: "ABP-030", Subtitle: true}},
+		{"rctd-460ch.mp4", Info{Number: "rctd-460", Subtitle: true}},
+		{"hnd-993ch字幕.mp4", Info{Number: "hnd-993", Subtitle: true}},
+		{"[中文字幕]FAX-146.mp4", Info{Number: "FAX-146", Subtitle: true}},
+		{"ABP-030-UC.mp4", Info{Number: "ABP-030", Subtitle: true, Uncensored: true}},
+		{"ABP-030B", Info{Number: "ABP-030", Part: 2}},
+		{"ABP-030-C-cd2.mp4", Info{Number: "ABP-030", Part: 2, Subtitle: true}},
+		{"FC2PPV-123456-1.mp4", Info{Number: "FC2-123456", Part: 1, Studio: "FC2"}},
+		{"[98t.tv]vema-181-4k-C.mp4", Info{Number: "vema-181", Subtitle: true, Resolution: "4K"}},
+		{"abp-030-2160p.mp4", Info{Number: "abp-030", Resolution: "4K"}},
+		{"SHKD-474 KATAGIRI ERIRIKA - FHD 1080P.mp4", Info{Number: "SHKD-474", Resolution: "1080p"}},
+		{"(無修正-流出) MXGS-247.mp4", Info{Number: "MXGS-247", Uncensored: true, Leak: true}},
+		{"ABP-030-leak.mp4", Info{Number: "ABP-030", Leak: true}},
+		{"[ThZu.Cc]080520-001-carib-720p.mp4", Info{Number: "080520-001", Uncensored: true, Resolution: "720p", Studio: "Caribbeancom"}},
+		{"heyzo-1031-1080p.mp4", Info{Number: "heyzo-1031", Uncensored: true, Resolution: "1080p", Studio: "HEYZO"}},
+		{"Tokyo Hot n0987.avi", Info{Number: "n0987", Uncensored: true, Studio: "Tokyo-Hot"}},
+		{"getchu-4023018.mp4", Info{Number: "getchu-4023018", Studio: "Getchu"}},
+		{"gcolle-845178.mp4", Info{Number: "gcolle-845178", Studio: "Gcolle"}},
+		{"mywife-1234.mp4", Info{Number: "mywife-1234", Studio: "Mywife"}},
+		{"1pondo 123120_001.mp4", Info{Number: "123120_001", Uncensored: true, Studio: "1Pondo"}},
+		{"PACO-345-C.mp4", Info{Number: "PACO-345", Subtitle: true}},
+		{"SHKD-474 Nakamura.mp4", Info{Number: "SHKD-474"}},
+	} {
+		unit.want.Filename = unit.orig
+		assert.Equal(t, &unit.want, Parse(unit.orig), unit.orig)
+	}
+}
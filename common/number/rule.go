@@ -0,0 +1,227 @@
+package number
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// maxRepeats limits the rewrites of a repeat rule.
+const maxRepeats = 100
+
+//go:embed rules.json
+var defaultRulesJSON []byte
+
+// Action is how a rule rewrites the number.
+type Action string
+
+const (
+	// ReplaceAction replaces all matches with the rewrite template.
+	ReplaceAction Action = "replace"
+	// ExtractAction replaces the whole string with the rewrite
+	// template expanded from the first match.
+	ExtractAction Action = "extract"
+	// RepeatAction replaces all matches repeatedly until no match.
+	RepeatAction Action = "repeat"
+)
+
+// Rule is a declarative step of number trimming.
+type Rule struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Pattern     string `json:"pattern"`
+	// Rewrite is a template of the regexp package, e.g., ${1}.
+	Rewrite string `json:"rewrite"`
+	Action  Action `json:"action,omitempty"`
+	// Priority orders the rules, higher runs first.
+	Priority int `json:"priority"`
+	// Group makes rules mutually exclusive, only the first
+	// matched rule of the same group is applied.
+	Group string `json:"group,omitempty"`
+	// Disabled removes the rule of the same name when merged.
+	Disabled bool `json:"disabled,omitempty"`
+
+	re *regexp.Regexp
+}
+
+func (r *Rule) compile() (err error) {
+	if r.Name == "" {
+		return errors.New("number: rule has no name")
+	}
+	switch r.Action {
+	case "":
+		r.Action = ReplaceAction
+	case ReplaceAction, ExtractAction, RepeatAction:
+	default:
+		return fmt.Errorf("number: rule %s has invalid action: %s", r.Name, r.Action)
+	}
+	if r.re, err = regexp.Compile(r.Pattern); err != nil {
+		return fmt.Errorf("number: rule %s: %w", r.Name, err)
+	}
+	return nil
+}
+
+// apply rewrites s and reports whether the pattern matched.
+func (r *Rule) apply(s string) (string, bool) {
+	switch r.Action {
+	case ExtractAction:
+		match := r.re.FindStringSubmatchIndex(s)
+		if match == nil {
+			return s, false
+		}
+		return string(r.re.ExpandString(nil, r.Rewrite, s, match)), true
+	case RepeatAction:
+		matched := false
+		for i := 0; i < maxRepeats && r.re.MatchString(s); i++ {
+			rewritten := r.re.ReplaceAllString(s, r.Rewrite)
+			matched = true
+			if rewritten == s {
+				break
+			}
+			s = rewritten
+		}
+		return s, matched
+	default:
+		if !r.re.MatchString(s) {
+			return s, false
+		}
+		return r.re.ReplaceAllString(s, r.Rewrite), true
+	}
+}
+
+// Step is a rewrite by a rule.
+type Step struct {
+	Rule   string `json:"rule"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// RuleSet is an ordered set of rules.
+type RuleSet struct {
+	rules []*Rule
+}
+
+// NewRuleSet compiles the rules and orders them by priority,
+// disabled rules are ignored.
+func NewRuleSet(rules ...*Rule) (*RuleSet, error) {
+	rs := &RuleSet{}
+	for _, rule := range rules {
+		if rule.Disabled {
+			continue
+		}
+		r := *rule // copy
+		if err := r.compile(); err != nil {
+			return nil, err
+		}
+		rs.rules = append(rs.rules, &r)
+	}
+	slices.SortStableFunc(rs.rules, func(a, b *Rule) int {
+		return b.Priority - a.Priority
+	})
+	return rs, nil
+}
+
+// Merge returns a new rule set with the rules added, rules of the same
+// names are replaced, or removed if disabled.
+func (rs *RuleSet) Merge(rules ...*Rule) (*RuleSet, error) {
+	merged := make([]*Rule, 0, len(rs.rules)+len(rules))
+	for _, rule := range rs.rules {
+		if !slices.ContainsFunc(rules, func(r *Rule) bool {
+			return strings.EqualFold(r.Name, rule.Name)
+		}) {
+			merged = append(merged, rule)
+		}
+	}
+	return NewRuleSet(append(merged, rules...)...)
+}
+
+// Rules returns the rules in order.
+func (rs *RuleSet) Rules() []*Rule {
+	return slices.Clone(rs.rules)
+}
+
+// Trim extracts the number from s, e.g., a filename.
+func (rs *RuleSet) Trim(s string) string {
+	s, _ = rs.Explain(s)
+	return s
+}
+
+// Explain is like Trim, but also returns the steps of the rules
+// that rewrote s.
+func (rs *RuleSet) Explain(s string) (string, []*Step) {
+	var (
+		steps   = make([]*Step, 0)
+		matched = make(map[string]bool)
+	)
+	for _, rule := range rs.rules {
+		if rule.Group != "" && matched[rule.Group] {
+			continue
+		}
+		rewritten, ok := rule.apply(s)
+		if !ok {
+			continue
+		}
+		if rule.Group != "" {
+			matched[rule.Group] = true
+		}
+		if rewritten != s {
+			steps = append(steps, &Step{Rule: rule.Name, Before: s, After: rewritten})
+			s = rewritten
+		}
+	}
+	return strings.TrimSpace(s), steps
+}
+
+// LoadRules decodes rules from a JSON array.
+func LoadRules(r io.Reader) ([]*Rule, error) {
+	var rules []*Rule
+	if err := json.NewDecoder(r).Decode(&rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+var defaultRuleSet = func() *RuleSet {
+	rules, err := LoadRules(bytes.NewReader(defaultRulesJSON))
+	if err != nil {
+		panic(err)
+	}
+	rs, err := NewRuleSet(rules...)
+	if err != nil {
+		panic(err)
+	}
+	return rs
+}()
+
+var currentRuleSet atomic.Pointer[RuleSet]
+
+func init() {
+	currentRuleSet.Store(defaultRuleSet)
+}
+
+// DefaultRules returns the embedded default rule set.
+func DefaultRules() *RuleSet {
+	return defaultRuleSet
+}
+
+// CurrentRules returns the rule set used by Trim.
+func CurrentRules() *RuleSet {
+	return currentRuleSet.Load()
+}
+
+// SetRules replaces the rule set used by Trim, e.g., merged with user rules.
+func SetRules(rs *RuleSet) {
+	currentRuleSet.Store(rs)
+}
+
+// Explain is like Trim, but also returns the rewrite steps.
+func Explain(s string) (string, []*Step) {
+	return CurrentRules().Explain(s)
+}
@@ -0,0 +1,84 @@
+package number
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplain(t *testing.T) {
+	number, steps := DefaultRules().Explain("[98t.tv]vema-181-4k-C.mp4")
+	assert.Equal(t, "vema-181", number)
+	assert.Equal(t, []*Step{
+		{Rule: "extension", Before: "[98t.tv]vema-181-4k-C.mp4", After: "[98t.tv]vema-181-4k-C"},
+		{Rule: "domain", Before: "[98t.tv]vema-181-4k-C", After: "[vema-181-4k-C"},
+		{Rule: "number-with-dashes", Before: "[vema-181-4k-C", After: "vema-181-4k"},
+		{Rule: "tags", Before: "vema-181-4k", After: "vema-181"},
+	}, steps)
+
+	number, steps = DefaultRules().Explain("rctd-460ch-ch.mp4")
+	assert.Equal(t, "rctd-460", number)
+	assert.Equal(t, []*Step{
+		{Rule: "extension", Before: "rctd-460ch-ch.mp4", After: "rctd-460ch-ch"},
+		{Rule: "suffixes", Before: "rctd-460ch-ch", After: "rctd-460"},
+	}, steps)
+
+	// only the first matched rule of a group is applied.
+	number, steps = DefaultRules().Explain("ssis00123")
+	assert.Equal(t, "ssis00123", number)
+	assert.Empty(t, steps)
+}
+
+func TestRuleSetMerge(t *testing.T) {
+	rules, err := LoadRules(strings.NewReader(`[
+		{"name": "my-studio", "pattern": "(?i)^mystudio[-_ ](\\d+)$", "rewrite": "MYS-${1}", "priority": 650},
+		{"name": "fc2-prefix", "disabled": true}
+	]`))
+	require.NoError(t, err)
+
+	rs, err := DefaultRules().Merge(rules...)
+	require.NoError(t, err)
+	assert.Len(t, rs.Rules(), len(DefaultRules().Rules()))
+
+	number, steps := rs.Explain("mystudio 0042.mp4")
+	assert.Equal(t, "MYS-0042", number)
+	if assert.Len(t, steps, 2) {
+		assert.Equal(t, "my-studio", steps[1].Rule)
+	}
+	assert.Equal(t, "FC2PPV-123456", rs.Trim("FC2PPV-123456"))
+
+	// rules of the same name are replaced.
+	rs, err = rs.Merge(&Rule{Name: "MY-STUDIO", Pattern: `(?i)^mystudio[-_ ](\d+)$`, Rewrite: "MYST-${1}", Priority: 650})
+	require.NoError(t, err)
+	assert.Equal(t, "MYST-0042", rs.Trim("mystudio 0042.mp4"))
+	assert.Equal(t, "vema-181", rs.Trim("[98t.tv]vema-181-4k-C.mp4"))
+}
+
+func TestRuleSetInvalid(t *testing.T) {
+	for _, rule := range []*Rule{
+		{Pattern: "a"},
+		{Name: "a", Pattern: "("},
+		{Name: "a", Pattern: "a", Action: "unknown"},
+	} {
+		_, err := NewRuleSet(rule)
+		assert.Error(t, err)
+	}
+
+	// repeat rules never loop forever.
+	rs, err := NewRuleSet(&Rule{Name: "a", Pattern: "a$", Rewrite: "aa", Action: RepeatAction})
+	require.NoError(t, err)
+	assert.Len(t, rs.Trim("a"), maxRepeats+1)
+}
+
+func TestSetRules(t *testing.T) {
+	t.Cleanup(func() { SetRules(DefaultRules()) })
+
+	rs, err := DefaultRules().Merge(&Rule{Name: "suffixes", Disabled: true})
+	require.NoError(t, err)
+	SetRules(rs)
+	assert.Equal(t, "ABP-030C", Trim("ABP-030C.mp4"))
+	SetRules(DefaultRules())
+	assert.Equal(t, "ABP-030", Trim("ABP-030C.mp4"))
+}
@@ -19,3 +19,25 @@ func Parallel[T any, R any](fn func(T) R, args ...T) []R {
 	wg.Wait()
 	return results
 }
+
+// ParallelN is like Parallel, but runs at most n functions at a time.
+func ParallelN[T any, R any](n int, fn func(T) R, args ...T) []R {
+	var wg sync.WaitGroup
+	results := make([]R, len(args))
+	sem := make(chan struct{}, max(n, 1))
+
+	for i, v := range args {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, v T) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i] = fn(v)
+		}(i, v)
+	}
+
+	wg.Wait()
+	return results
+}
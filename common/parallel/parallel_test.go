@@ -1,6 +1,7 @@
 package parallel
 
 import (
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -30,6 +31,26 @@ func TestParallel(t *testing.T) {
 	}
 }
 
+func TestParallelN(t *testing.T) {
+	var running, peak atomic.Int32
+	fn := func(x int) int {
+		n := running.Add(1)
+		for {
+			if p := peak.Load(); n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		return x * x
+	}
+
+	args := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	assert.Equal(t, []int{1, 4, 9, 16, 25, 36, 49, 64}, ParallelN(3, fn, args...))
+	assert.LessOrEqual(t, peak.Load(), int32(3))
+	assert.Empty(t, ParallelN(3, fn))
+}
+
 func mockSlowFn(x int) int {
 	time.Sleep(10 * time.Millisecond)
 	return x * x
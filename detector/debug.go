@@ -0,0 +1,152 @@
+package detector
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+
+	pigo "github.com/esimov/pigo/core"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/metatube-community/metatube-sdk-go/common/cluster"
+	"github.com/metatube-community/metatube-sdk-go/detector/internal/position"
+)
+
+// DebugFace is a detected face in the coordinates of the original image.
+type DebugFace struct {
+	X      int     `json:"x"`
+	Y      int     `json:"y"`
+	Size   int     `json:"size"`
+	Score  float64 `json:"score"`
+	Weight float64 `json:"weight"`
+}
+
+// Rect returns the bounding box of the face.
+func (f DebugFace) Rect() image.Rectangle {
+	return image.Rect(
+		f.X-f.Size/2, f.Y-f.Size/2,
+		f.X+f.Size/2, f.Y+f.Size/2,
+	)
+}
+
+// DebugGroup is a cluster of face positions along the dominant axis.
+type DebugGroup struct {
+	Position  float64   `json:"position"`
+	Positions []float64 `json:"positions"`
+	Weight    float64   `json:"weight"`
+}
+
+// DebugResult describes how FindPrimaryFaceAxisRatio reached its decision.
+type DebugResult struct {
+	Axis     string       `json:"axis"`
+	Faces    []DebugFace  `json:"faces"`
+	Groups   []DebugGroup `json:"groups"`
+	Position float64      `json:"position"`
+	Found    bool         `json:"found"`
+}
+
+// DebugPrimaryFaceAxisRatio runs FindPrimaryFaceAxisRatioWithDetector and
+// collects the detected faces and clustered groups for inspection.
+func DebugPrimaryFaceAxisRatio(d Detector, img image.Image, ratio float64, advanced bool) *DebugResult {
+	result := &DebugResult{}
+	result.Position, result.Found = FindPrimaryFaceAxisRatioWithDetector(d, img, ratio, advanced,
+		func(inner image.Image, faces []pigo.Detection, groups []cluster.Group[position.WeightedVector, float64]) {
+			// scale back to the coordinates of the original image.
+			scale := float64(img.Bounds().Dx()) / float64(inner.Bounds().Dx())
+
+			if dominantAxisByRatio(inner, ratio) == 0 {
+				result.Axis = "x"
+			} else {
+				result.Axis = "y"
+			}
+			for _, face := range faces {
+				result.Faces = append(result.Faces, DebugFace{
+					X:      int(float64(face.Col) * scale),
+					Y:      int(float64(face.Row) * scale),
+					Size:   int(float64(face.Scale) * scale),
+					Score:  float64(face.Q),
+					Weight: computeFaceWeight(face),
+				})
+			}
+			// groups are already sorted by weight.
+			for _, group := range groups {
+				g := DebugGroup{
+					Position: float64(position.WeightedAverageVector(group.Items).At(0)),
+				}
+				for _, item := range group.Items {
+					g.Positions = append(g.Positions, float64(item.At(0)))
+					g.Weight += item.Weight()
+				}
+				result.Groups = append(result.Groups, g)
+			}
+		})
+	return result
+}
+
+// Annotate draws the faces, groups and the crop rectangle onto a copy of img.
+func (r *DebugResult) Annotate(img image.Image, crop image.Rectangle) image.Image {
+	var (
+		red    = color.RGBA{R: 255, A: 255}
+		green  = color.RGBA{G: 255, A: 255}
+		blue   = color.RGBA{B: 255, A: 255}
+		yellow = color.RGBA{R: 255, G: 255, A: 255}
+	)
+
+	b := img.Bounds()
+	rgba := image.NewRGBA(b)
+	draw.Draw(rgba, b, img, b.Min, draw.Src)
+
+	// group positions, the dominant one in yellow.
+	for i, group := range r.Groups {
+		c := blue
+		if i == 0 && r.Found {
+			c = yellow
+		}
+		if r.Axis == "x" {
+			x := b.Min.X + int(group.Position*float64(b.Dx()))
+			drawRect(rgba, image.Rect(x, b.Min.Y, x+1, b.Max.Y), c, 1)
+		} else {
+			y := b.Min.Y + int(group.Position*float64(b.Dy()))
+			drawRect(rgba, image.Rect(b.Min.X, y, b.Max.X, y+1), c, 1)
+		}
+	}
+
+	for _, face := range r.Faces {
+		rect := face.Rect().Add(b.Min)
+		drawRect(rgba, rect, red, 2)
+		drawLabel(rgba, rect.Min.Add(image.Pt(2, 12)),
+			fmt.Sprintf("%.1f", face.Score), yellow)
+	}
+
+	drawRect(rgba, crop, green, 3)
+	return rgba
+}
+
+func drawRect(dst draw.Image, r image.Rectangle, c color.Color, thickness int) {
+	r = r.Intersect(dst.Bounds())
+	if r.Empty() {
+		return
+	}
+	src := image.NewUniform(c)
+	for _, edge := range []image.Rectangle{
+		image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+thickness), // top
+		image.Rect(r.Min.X, r.Max.Y-thickness, r.Max.X, r.Max.Y), // bottom
+		image.Rect(r.Min.X, r.Min.Y, r.Min.X+thickness, r.Max.Y), // left
+		image.Rect(r.Max.X-thickness, r.Min.Y, r.Max.X, r.Max.Y), // right
+	} {
+		draw.Draw(dst, edge.Intersect(r), src, image.Point{}, draw.Src)
+	}
+}
+
+func drawLabel(dst draw.Image, pt image.Point, label string, c color.Color) {
+	d := &font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(pt.X, pt.Y),
+	}
+	d.DrawString(label)
+}
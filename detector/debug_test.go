@@ -0,0 +1,27 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugPrimaryFaceAxisRatio(t *testing.T) {
+	img, err := loadTestImage("809ee47a17a7938ebd6d908244b962c8")
+	require.NoError(t, err)
+
+	pos, found := FindPrimaryFaceAxisRatio(img, xRatio, true)
+	result := DebugPrimaryFaceAxisRatio(Default(), img, xRatio, true)
+	assert.Equal(t, found, result.Found)
+	assert.InDelta(t, pos, result.Position, 1e-9)
+	assert.Equal(t, "x", result.Axis)
+	require.NotEmpty(t, result.Groups)
+	assert.InDelta(t, pos, result.Groups[0].Position, 1e-9)
+	for _, face := range result.Faces {
+		assert.True(t, face.Rect().Overlaps(img.Bounds()))
+	}
+
+	annotated := result.Annotate(img, img.Bounds())
+	assert.Equal(t, img.Bounds(), annotated.Bounds())
+}
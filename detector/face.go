@@ -0,0 +1,49 @@
+package detector
+
+import (
+	"image"
+	"math"
+
+	"github.com/disintegration/imaging"
+)
+
+// FindPrimaryFace returns the bounding box of the dominant face, i.e.,
+// the face nearest to the heaviest cluster of faces, in the coordinates
+// of the given image.
+func FindPrimaryFace(d Detector, img image.Image, advanced bool) (image.Rectangle, bool) {
+	src := img
+	// limit max width for performance improvement.
+	if img.Bounds().Dx() > maxImageWidth {
+		img = imaging.Resize(
+			img, maxImageWidth, 0,
+			imaging.NearestNeighbor, /* fastest */
+		)
+	}
+	faces := d.Detect(img, advanced)
+	// cluster faces in both X and Y dimensions.
+	vec, ok := getDominantVector(clusterFaceVectors(img, faces, 0, 1))
+	if !ok || vec.Dim() != 2 {
+		return image.Rectangle{}, false
+	}
+
+	best, minDistance := -1, math.Inf(1)
+	for i, face := range faces {
+		if distance := extractFaceVector(img, face).DistanceTo(vec); distance < minDistance {
+			best, minDistance = i, distance
+		}
+	}
+	if best < 0 {
+		return image.Rectangle{}, false
+	}
+
+	// scale back to the coordinates of the source image.
+	var (
+		face   = faces[best]
+		scale  = float64(src.Bounds().Dx()) / float64(img.Bounds().Dx())
+		x, y   = int(float64(face.Col) * scale), int(float64(face.Row) * scale)
+		radius = int(float64(face.Scale) * scale / 2)
+	)
+	return image.Rect(x-radius, y-radius, x+radius, y+radius).
+		Add(src.Bounds().Min).
+		Intersect(src.Bounds()), true
+}
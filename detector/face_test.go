@@ -0,0 +1,23 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindPrimaryFace(t *testing.T) {
+	img, err := loadTestImage("809ee47a17a7938ebd6d908244b962c8")
+	require.NoError(t, err)
+
+	rect, found := FindPrimaryFace(Default(), img, true)
+	require.True(t, found)
+	assert.True(t, rect.In(img.Bounds()))
+	assert.False(t, rect.Empty())
+
+	// consistent with the primary face axis position.
+	pos, _ := FindPrimaryFaceAxisRatio(img, xRatio, true)
+	center := float64(rect.Min.X+rect.Max.X) / 2 / float64(img.Bounds().Dx())
+	assert.InDelta(t, pos, center, 0.1)
+}
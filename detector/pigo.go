@@ -0,0 +1,144 @@
+package detector
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/disintegration/imaging"
+	pigo "github.com/esimov/pigo/core"
+
+	"github.com/metatube-community/metatube-sdk-go/collection/slices"
+	"github.com/metatube-community/metatube-sdk-go/common/parallel"
+	"github.com/metatube-community/metatube-sdk-go/detector/internal/geomath"
+)
+
+var _ Detector = (*PigoDetector)(nil)
+
+// PigoDetector detects faces with a pigo cascade classifier.
+type PigoDetector struct {
+	classifier *pigo.Pigo
+}
+
+// NewPigoDetector unpacks a binary pigo cascade, e.g., one trained
+// for stylized faces, into a detector.
+func NewPigoDetector(cascade []byte) (*PigoDetector, error) {
+	classifier, err := pigo.NewPigo().Unpack(cascade)
+	if err != nil {
+		return nil, err
+	}
+	return &PigoDetector{classifier: classifier}, nil
+}
+
+func (d *PigoDetector) Detect(img image.Image, advanced bool) []pigo.Detection {
+	if !advanced {
+		// simple face detection.
+		return d.DetectFaces(img)
+	}
+	// detect faces from different angles.
+	return d.DetectFacesWithMultiAngles(img)
+}
+
+func (d *PigoDetector) detectFaces(params *pigo.CascadeParams, angles ...float64) []pigo.Detection {
+	// initialize angles if empty.
+	if len(angles) == 0 {
+		angles = []float64{0.0}
+	}
+
+	detect := func(angle float64) []pigo.Detection {
+		// Run the classifier over the obtained leaf nodes and return the detection results.
+		// The result contains quadruplets representing the row, column, scale and detection score.
+		return d.classifier.RunCascade(*params, angle)
+	}
+	return slices.Flatten(parallel.Parallel(detect, angles...))
+}
+
+func (d *PigoDetector) DetectFaces(img image.Image, angles ...float64) []pigo.Detection {
+	imgParams := pigo.ImageParams{
+		Pixels: pigo.RgbToGrayscale(img),
+		Rows:   img.Bounds().Dy(),
+		Cols:   img.Bounds().Dx(),
+		Dim:    img.Bounds().Dx(),
+	}
+	for _, params := range []pigo.CascadeParams{
+		{
+			MinSize:     minFaceSize,
+			MaxSize:     maxFaceSize,
+			ShiftFactor: 0.10,
+			ScaleFactor: 1.08,
+			ImageParams: imgParams,
+		},
+		/*
+			// extra params for better accuracy.
+			{
+				MinSize:     minFaceSize,
+				MaxSize:     maxFaceSize,
+				ShiftFactor: 0.09,
+				ScaleFactor: 1.0,
+				ImageParams: imgParams,
+			},
+		*/
+	} {
+		if faces := d.detectFaces(&params, angles...); len(faces) > 0 {
+			return faces
+		}
+	}
+	return nil
+}
+
+func (d *PigoDetector) DetectFacesWithRotation(img image.Image, rotatedAngle float64, angles ...float64) []pigo.Detection {
+	var (
+		origWidth  = img.Bounds().Dx()
+		origHeight = img.Bounds().Dy()
+	)
+	rotatedImg := imaging.Rotate(img, rotatedAngle, color.Transparent)
+	faces := d.DetectFaces(rotatedImg, angles...)
+	if rotatedAngle == 0 {
+		return faces
+	}
+	invAngle := math.Mod(360-rotatedAngle, 360)
+	for i := range faces {
+		x, y := geomath.RotatePoint(
+			faces[i].Col, faces[i].Row,
+			rotatedImg.Bounds().Dx(),
+			rotatedImg.Bounds().Dy(),
+			invAngle,
+		)
+		x = max(min(x, origWidth), 0)
+		y = max(min(y, origHeight), 0)
+		faces[i].Col, faces[i].Row = x, y
+	}
+	return faces
+}
+
+func (d *PigoDetector) DetectFacesWithMultiAngles(img image.Image) []pigo.Detection {
+	fixedAngles := []float64{ // in radians
+		0.00,
+		0.13,
+		0.87,
+	}
+	rotatedAngles := []float64{ // in degrees
+		0,
+		90,
+		270,
+	}
+	detect := func(angle float64) []pigo.Detection {
+		return d.DetectFacesWithRotation(img, angle, fixedAngles...)
+	}
+	return slices.Flatten(parallel.Parallel(detect, rotatedAngles...))
+}
+
+// DetectFaces detects faces with the embedded pigo cascade.
+func DetectFaces(img image.Image, angles ...float64) []pigo.Detection {
+	return defaultPigoDetector.DetectFaces(img, angles...)
+}
+
+// DetectFacesWithRotation detects faces with the embedded pigo cascade.
+func DetectFacesWithRotation(img image.Image, rotatedAngle float64, angles ...float64) []pigo.Detection {
+	return defaultPigoDetector.DetectFacesWithRotation(img, rotatedAngle, angles...)
+}
+
+// DetectFacesWithMultiAngles detects faces with the embedded pigo cascade.
+func DetectFacesWithMultiAngles(img image.Image) []pigo.Detection {
+	return defaultPigoDetector.DetectFacesWithMultiAngles(img)
+}
@@ -0,0 +1,55 @@
+package detector
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/metatube-community/metatube-sdk-go/collection/maps"
+)
+
+// Built-in detector backends, selectable per provider,
+// e.g., `export MT_MOVIE_PROVIDER_GETCHU__DETECTOR=skin`.
+const (
+	PigoBackend = "pigo"
+	SkinBackend = "skin"
+)
+
+var (
+	detectorsMu sync.RWMutex
+	detectors   = maps.NewCaseInsensitiveMap[Detector]()
+)
+
+var defaultPigoDetector *PigoDetector
+
+func init() {
+	defaultPigoDetector, _ = NewPigoDetector(cascade)
+	Register(PigoBackend, defaultPigoDetector)
+	Register(SkinBackend, &SkinDetector{})
+}
+
+// Register registers a detector backend by name, e.g., a pigo
+// cascade trained for anime faces, it overrides any existing one.
+func Register(name string, d Detector) {
+	detectorsMu.Lock()
+	defer detectorsMu.Unlock()
+	detectors.Set(name, d)
+}
+
+// Lookup returns the detector backend registered by name.
+func Lookup(name string) (Detector, bool) {
+	detectorsMu.RLock()
+	defer detectorsMu.RUnlock()
+	return detectors.Get(name)
+}
+
+// Backends returns the names of all registered detector backends.
+func Backends() []string {
+	detectorsMu.RLock()
+	defer detectorsMu.RUnlock()
+	return slices.Sorted(detectors.Keys())
+}
+
+// Default returns the default pigo face detector.
+func Default() Detector {
+	return defaultPigoDetector
+}
@@ -0,0 +1,125 @@
+package detector
+
+import (
+	"image"
+	"math"
+
+	"github.com/disintegration/imaging"
+)
+
+const (
+	// maxSaliencyImageWidth limits the image width for saliency estimation,
+	// only the coarse distribution of details matters.
+	maxSaliencyImageWidth = 160
+	// saliencyBins is the number of gray-level bins to compute entropy.
+	saliencyBins = 16
+	// minSaliencyContrast is the min relative difference between
+	// the most and the least salient windows to be trusted.
+	minSaliencyContrast = 0.05
+)
+
+// FindSalientAxisRatio estimates the most interesting window along the
+// dominant axis of the given crop ratio by the edge density and the gray
+// level entropy of each row or column, and returns the window center as
+// a position ratio. It's meant to be used when no face is found.
+func FindSalientAxisRatio(img image.Image, ratio float64) (float64, bool) {
+	if ratio <= 0 {
+		return 0, false
+	}
+	if img.Bounds().Dx() > maxSaliencyImageWidth {
+		img = imaging.Resize(img, maxSaliencyImageWidth, 0, imaging.Box)
+	}
+	gray := imaging.Grayscale(img)
+
+	var (
+		width  = gray.Bounds().Dx()
+		height = gray.Bounds().Dy()
+		axis   = dominantAxisByRatio(gray, ratio)
+	)
+	if width < 3 || height < 3 {
+		return 0, false
+	}
+
+	// window size along the dominant axis.
+	var n, window int
+	if axis == 0 /* X */ {
+		n, window = width, int(float64(height)*ratio)
+	} else /* Y */ {
+		n, window = height, int(float64(width)/ratio)
+	}
+	if window <= 0 || window >= n {
+		return 0, false // no cropping needed.
+	}
+
+	profile := saliencyProfile(gray, axis)
+
+	// sliding window sums via prefix sums.
+	prefix := make([]float64, n+1)
+	for i, v := range profile {
+		prefix[i+1] = prefix[i] + v
+	}
+	var (
+		best, worst = math.Inf(-1), math.Inf(1)
+		bestStart   int
+	)
+	for start := 0; start+window <= n; start++ {
+		sum := prefix[start+window] - prefix[start]
+		if sum > best {
+			best, bestStart = sum, start
+		}
+		worst = min(worst, sum)
+	}
+	if best <= 0 || (best-worst)/best < minSaliencyContrast {
+		return 0, false // flat image, nothing stands out.
+	}
+	return (float64(bestStart) + float64(window)/2) / float64(n), true
+}
+
+// saliencyProfile returns the saliency of each column (axis 0) or row
+// (axis 1), which is the mean edge magnitude weighted by the entropy.
+func saliencyProfile(gray *image.NRGBA, axis int) []float64 {
+	var (
+		width  = gray.Bounds().Dx()
+		height = gray.Bounds().Dy()
+		pix    = func(x, y int) float64 {
+			x = max(min(x, width-1), 0)
+			y = max(min(y, height-1), 0)
+			return float64(gray.Pix[y*gray.Stride+x*4])
+		}
+	)
+
+	n, m := width, height // lines, pixels per line.
+	if axis == 1 {
+		n, m = height, width
+	}
+	profile := make([]float64, n)
+	for i := 0; i < n; i++ {
+		var (
+			edges float64
+			hist  [saliencyBins]int
+		)
+		for j := 0; j < m; j++ {
+			x, y := i, j
+			if axis == 1 {
+				x, y = j, i
+			}
+			// sobel operator.
+			gx := pix(x+1, y-1) + 2*pix(x+1, y) + pix(x+1, y+1) -
+				pix(x-1, y-1) - 2*pix(x-1, y) - pix(x-1, y+1)
+			gy := pix(x-1, y+1) + 2*pix(x, y+1) + pix(x+1, y+1) -
+				pix(x-1, y-1) - 2*pix(x, y-1) - pix(x+1, y-1)
+			edges += math.Hypot(gx, gy)
+			hist[int(pix(x, y))*saliencyBins/256]++
+		}
+		var entropy float64
+		for _, c := range hist {
+			if c == 0 {
+				continue
+			}
+			p := float64(c) / float64(m)
+			entropy -= p * math.Log2(p)
+		}
+		profile[i] = edges / float64(m) * entropy / math.Log2(saliencyBins)
+	}
+	return profile
+}
@@ -0,0 +1,44 @@
+package detector
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindSalientAxisRatio(t *testing.T) {
+	// checkerboard pattern within the given rectangle.
+	textured := func(w, h int, r image.Rectangle) image.Image {
+		img := image.NewGray(image.Rect(0, 0, w, h))
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				if (image.Point{X: x, Y: y}).In(r) && (x/4+y/4)%2 == 0 {
+					img.SetGray(x, y, color.Gray{Y: 255})
+				}
+			}
+		}
+		return img
+	}
+
+	for _, unit := range []struct {
+		name  string
+		img   image.Image
+		ratio float64
+		pos   float64
+		found bool
+	}{
+		{"x-axis", textured(300, 100, image.Rect(200, 0, 250, 100)), 2.0 / 3, 0.75, true},
+		{"y-axis", textured(100, 300, image.Rect(0, 20, 100, 80)), 16.0 / 9, 0.17, true},
+		{"flat", textured(300, 100, image.Rectangle{}), 2.0 / 3, 0, false},
+		{"no-crop", textured(100, 100, image.Rect(0, 0, 50, 50)), 1, 0, false},
+	} {
+		t.Run(unit.name, func(t *testing.T) {
+			pos, found := FindSalientAxisRatio(unit.img, unit.ratio)
+			if assert.Equal(t, unit.found, found) && found {
+				assert.InDelta(t, unit.pos, pos, tolerance)
+			}
+		})
+	}
+}
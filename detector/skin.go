@@ -0,0 +1,130 @@
+package detector
+
+import (
+	"image"
+	"image/color"
+
+	pigo "github.com/esimov/pigo/core"
+)
+
+var _ Detector = (*SkinDetector)(nil)
+
+const (
+	// skinCellSize is the side of the grid cells in pixels.
+	skinCellSize = 8
+	// skinCellRatio is the min ratio of skin pixels in a skin cell.
+	skinCellRatio = 0.5
+)
+
+// SkinDetector is a CPU-only heuristic backend that locates people by
+// skin-tone regions rather than facial features. It is less precise than
+// cascades on photos, but copes with stylized faces and side profiles of
+// anime or illustration covers, where cascades find nothing at all.
+type SkinDetector struct{}
+
+func (SkinDetector) Detect(img image.Image, _ bool) []pigo.Detection {
+	var (
+		b    = img.Bounds()
+		cols = b.Dx() / skinCellSize
+		rows = b.Dy() / skinCellSize
+	)
+	if cols == 0 || rows == 0 {
+		return nil
+	}
+
+	// mark grid cells mostly covered by skin tones.
+	grid := make([]bool, cols*rows)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			var n, total int
+			for y := 0; y < skinCellSize; y += 2 {
+				for x := 0; x < skinCellSize; x += 2 {
+					total++
+					if isSkinColor(img.At(
+						b.Min.X+c*skinCellSize+x,
+						b.Min.Y+r*skinCellSize+y)) {
+						n++
+					}
+				}
+			}
+			grid[r*cols+c] = float64(n) >= float64(total)*skinCellRatio
+		}
+	}
+
+	var (
+		faces   []pigo.Detection
+		visited = make([]bool, len(grid))
+		minSize = (minFaceSize + skinCellSize - 1) / skinCellSize
+	)
+	for i := range grid {
+		if !grid[i] || visited[i] {
+			continue
+		}
+		cells := floodFill(grid, visited, cols, rows, i)
+		if len(cells) < minSize*minSize {
+			continue // too small to be a person.
+		}
+
+		minR, maxR, minC, maxC := rows, 0, cols, 0
+		for _, cell := range cells {
+			r, c := cell/cols, cell%cols
+			minR, maxR = min(minR, r), max(maxR, r)
+			minC, maxC = min(minC, c), max(maxC, c)
+		}
+		w, h := maxC-minC+1, maxR-minR+1
+		// heads are usually on top of skin regions, so only
+		// the top square of the region is used as the face.
+		side := min(w, h, maxFaceSize/skinCellSize)
+		var sumC, n int
+		for _, cell := range cells {
+			if r := cell / cols; r < minR+side {
+				sumC += cell % cols
+				n++
+			}
+		}
+		faces = append(faces, pigo.Detection{
+			Row:   (minR*2 + side) * skinCellSize / 2,
+			Col:   (sumC*2 + n) * skinCellSize / (2 * n),
+			Scale: side * skinCellSize,
+			// density of the region scaled to the range of pigo scores.
+			Q: float32(len(cells)) / float32(w*h) * 10,
+		})
+	}
+	return faces
+}
+
+// floodFill collects the 4-connected cells starting from the given cell.
+func floodFill(grid, visited []bool, cols, rows, start int) []int {
+	var (
+		cells []int
+		stack = []int{start}
+	)
+	visited[start] = true
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		cells = append(cells, i)
+
+		r, c := i/cols, i%cols
+		for _, n := range [][2]int{{r - 1, c}, {r + 1, c}, {r, c - 1}, {r, c + 1}} {
+			if n[0] < 0 || n[0] >= rows || n[1] < 0 || n[1] >= cols {
+				continue
+			}
+			if j := n[0]*cols + n[1]; grid[j] && !visited[j] {
+				visited[j] = true
+				stack = append(stack, j)
+			}
+		}
+	}
+	return cells
+}
+
+// isSkinColor reports whether the color falls in the common skin range
+// of the YCbCr color space, which is mostly independent of brightness.
+func isSkinColor(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	y, cb, cr := color.RGBToYCbCr(uint8(r>>8), uint8(g>>8), uint8(b>>8))
+	return y > 40 &&
+		cb >= 77 && cb <= 127 &&
+		cr >= 133 && cr <= 173
+}
@@ -0,0 +1,163 @@
+package engine
+
+import (
+	"fmt"
+	"image"
+	"strings"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+
+	"github.com/metatube-community/metatube-sdk-go/common/cluster"
+	"github.com/metatube-community/metatube-sdk-go/common/parallel"
+	"github.com/metatube-community/metatube-sdk-go/engine/providerid"
+	"github.com/metatube-community/metatube-sdk-go/imageutil"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+const (
+	// maxCoverCandidates limits the number of providers to fetch covers from.
+	maxCoverCandidates = 10
+	// maxCoverFetches limits the concurrent fetches of candidates.
+	maxCoverFetches = 4
+	// bestCoverTTL is how long a selected best cover is reused, so that
+	// candidates are not fetched again for every uncached request.
+	bestCoverTTL      = 7 * 24 * time.Hour
+	bestCoverCapacity = 10000
+)
+
+// bestCoverChoice is the selected best cover of a movie.
+type bestCoverChoice struct {
+	Provider string
+	URL      string
+}
+
+func newBestCoverCache() *ttlcache.Cache[string, *bestCoverChoice] {
+	return ttlcache.New[string, *bestCoverChoice](
+		ttlcache.WithTTL[string, *bestCoverChoice](bestCoverTTL),
+		ttlcache.WithCapacity[string, *bestCoverChoice](bestCoverCapacity),
+	)
+}
+
+func bestCoverKey(info *model.MovieInfo, thumb bool) string {
+	return fmt.Sprintf("%s:%s:%t", info.Provider, info.ID, thumb)
+}
+
+var _ cluster.Locatable[*coverCandidate, int] = (*coverCandidate)(nil)
+
+type coverCandidate struct {
+	provider mt.MovieProvider
+	url      string
+	img      image.Image
+	hash     uint64
+}
+
+func (c *coverCandidate) DistanceTo(o *coverCandidate) int {
+	return imageutil.HashDistance(c.hash, o.hash)
+}
+
+func (c *coverCandidate) resolution() int {
+	return c.img.Bounds().Dx() * c.img.Bounds().Dy()
+}
+
+// getBestMovieCover collects the covers of the same movie number from all
+// providers, clusters them by perceptual hash, and returns the highest
+// resolution cover of the cluster that the given cover belongs to. It
+// returns nil if no cover can be fetched. The selection is reused for
+// bestCoverTTL.
+func (e *Engine) getBestMovieCover(info *model.MovieInfo, thumb bool) *coverCandidate {
+	key := bestCoverKey(info, thumb)
+	if item := e.bestCovers.Get(key); item != nil {
+		choice := item.Value()
+		if provider, err := e.GetMovieProviderByName(choice.Provider); err == nil {
+			if img, err := e.getImageByURL(provider, choice.URL); err == nil {
+				return &coverCandidate{provider: provider, url: choice.URL, img: img}
+			}
+		}
+		// select again if the selected cover is no longer available.
+		e.bestCovers.Delete(key)
+	}
+	best := e.selectBestMovieCover(info, thumb)
+	if best != nil {
+		e.bestCovers.Set(key, &bestCoverChoice{
+			Provider: best.provider.Name(),
+			URL:      best.url,
+		}, ttlcache.DefaultTTL)
+	}
+	return best
+}
+
+func (e *Engine) selectBestMovieCover(info *model.MovieInfo, thumb bool) *coverCandidate {
+	pids := []providerid.ProviderID{{Provider: info.Provider, ID: info.ID}}
+	if results, err := e.SearchMovieAll(info.Number, true); err == nil {
+		for _, result := range results {
+			if len(pids) >= maxCoverCandidates {
+				break
+			}
+			if !strings.EqualFold(result.Number, info.Number) ||
+				(result.Provider == info.Provider && result.ID == info.ID) {
+				continue
+			}
+			pids = append(pids, providerid.ProviderID{Provider: result.Provider, ID: result.ID})
+		}
+	}
+
+	var (
+		own        *coverCandidate
+		candidates []*coverCandidate
+	)
+	for i, candidate := range parallel.ParallelN(maxCoverFetches, func(pid providerid.ProviderID) *coverCandidate {
+		provider, err := e.GetMovieProviderByName(pid.Provider)
+		if err != nil {
+			return nil
+		}
+		other, err := e.getMovieInfoByProviderID(provider, pid.ID, true)
+		if err != nil {
+			return nil
+		}
+		url := preferredMovieImageURL(other, thumb)
+		img, err := e.getImageByURL(provider, url)
+		if err != nil {
+			return nil
+		}
+		hash, err := imageutil.PerceptionHash(img)
+		if err != nil {
+			return nil
+		}
+		return &coverCandidate{provider: provider, url: url, img: img, hash: hash}
+	}, pids...) {
+		if candidate == nil {
+			continue
+		}
+		if i == 0 {
+			own = candidate
+		}
+		candidates = append(candidates, candidate)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	groups := cluster.GroupByDistance(candidates, imageutil.SimilarHashDistance)
+	// prefer the group of the given cover, otherwise the largest group.
+	cluster.SortGroupsBySize(groups)
+	group := groups[0]
+	for _, g := range groups {
+		if g.Items[0] == own /* always the first one */ {
+			group = g
+			break
+		}
+	}
+
+	best := group.Items[0]
+	for _, candidate := range group.Items[1:] {
+		if candidate.resolution() > best.resolution() {
+			best = candidate
+		}
+	}
+	e.logger.Printf("Select best cover for %s from %d candidates: %s(%dx%d)",
+		info.Number, len(candidates), best.provider.Name(),
+		best.img.Bounds().Dx(), best.img.Bounds().Dy())
+	return best
+}
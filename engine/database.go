@@ -8,9 +8,17 @@ import (
 )
 
 func (e *Engine) DBAutoMigrate(v bool) error {
-	if !v {
-		return nil
+	if v {
+		if err := e.dbAutoMigrate(); err != nil {
+			return err
+		}
 	}
+	// tables are ready, warm up the cover hash index.
+	e.loadCoverHashIndexAsync()
+	return nil
+}
+
+func (e *Engine) dbAutoMigrate() error {
 	// Create Case-Insensitive Collation for Postgres.
 	if e.DBDriver() == database.Postgres {
 		e.db.Exec(`CREATE COLLATION IF NOT EXISTS NOCASE (
@@ -22,6 +30,10 @@ func (e *Engine) DBAutoMigrate(v bool) error {
 		&model.MovieInfo{},
 		&model.ActorInfo{},
 		&model.MovieReviewInfo{},
+		&model.MovieCoverHash{},
+		&model.MovieCropPosition{},
+		&model.TranslationCache{},
+		&model.GlossaryTerm{},
 	)
 }
 
@@ -59,6 +59,10 @@ func (e *engine) AutoMigrate() error {
 		&model.MovieInfo{},
 		&model.ActorInfo{},
 		&model.MovieReviewInfo{},
+		&model.MovieCoverHash{},
+		&model.MovieCropPosition{},
+		&model.TranslationCache{},
+		&model.GlossaryTerm{},
 	); err != nil {
 		return err
 	}
@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"image"
+
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	R "github.com/metatube-community/metatube-sdk-go/constant"
+	"github.com/metatube-community/metatube-sdk-go/detector"
+	"github.com/metatube-community/metatube-sdk-go/engine/providerid"
+	"github.com/metatube-community/metatube-sdk-go/imageutil"
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// PrimaryImageDebugInfo explains how the primary image of a movie is cropped.
+type PrimaryImageDebugInfo struct {
+	URL       string                `json:"url"`
+	Width     int                   `json:"width"`
+	Height    int                   `json:"height"`
+	Ratio     float64               `json:"ratio"`
+	Auto      bool                  `json:"auto"`
+	Method    string                `json:"method"`
+	Position  float64               `json:"position"`
+	Crop      image.Rectangle       `json:"crop"`
+	Detection *detector.DebugResult `json:"detection"`
+	// Cached is the cached crop position, if any, which is served
+	// instead of the fresh detection.
+	Cached *model.MovieCropPosition `json:"cached,omitempty"`
+}
+
+// DebugMoviePrimaryImage is like GetMoviePrimaryImage, but returns the
+// uncropped source image along with the face detection and crop details.
+// Faces are always detected, even when auto positioning does not apply,
+// but the crop is made at the cached position if it's valid, as served.
+func (e *Engine) DebugMoviePrimaryImage(pid providerid.ProviderID, ratio, pos float64) (image.Image, *PrimaryImageDebugInfo, error) {
+	url, info, err := e.getPreferredMovieImageURLAndInfo(pid, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ratio < 0 /* default primary ratio */ {
+		ratio = R.PrimaryImageRatio
+	}
+	var auto bool
+	if pos < 0 /* manual position disabled */ {
+		pos = defaultMoviePrimaryImagePosition
+		auto = number.RequiresFaceDetection(info.Number)
+	}
+	provider := e.MustGetMovieProviderByName(pid.Provider)
+	img, err := e.getImageByURL(provider, url)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := detector.DebugPrimaryFaceAxisRatio(e.GetDetector(provider), img, ratio, true)
+	var cached *model.MovieCropPosition
+	if auto && ratio == R.PrimaryImageRatio /* only cached ratio */ {
+		var ok bool
+		if cached, ok = e.getMovieCropPosition(info, ratio, false); !ok {
+			cached = nil // stale.
+		}
+	}
+	method := fixedCropMethod
+	if cached != nil {
+		if cached.Method != fixedCropMethod {
+			pos = cached.Position
+		}
+		method = cached.Method
+	} else if auto {
+		if result.Found {
+			pos, method = result.Position, faceCropMethod
+		} else if axisR, ok := detector.FindSalientAxisRatio(img, ratio); ok {
+			pos, method = axisR, saliencyCropMethod
+		}
+	}
+	return img, &PrimaryImageDebugInfo{
+		URL:       url,
+		Width:     img.Bounds().Dx(),
+		Height:    img.Bounds().Dy(),
+		Ratio:     ratio,
+		Auto:      auto,
+		Method:    method,
+		Position:  pos,
+		Crop:      imageutil.CropRectPosition(img.Bounds(), ratio, pos),
+		Detection: result,
+		Cached:    cached,
+	}, nil
+}
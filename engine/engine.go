@@ -7,13 +7,16 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/jellydator/ttlcache/v3"
 	"gorm.io/gorm"
 
 	"github.com/metatube-community/metatube-sdk-go/collection/maps"
 	"github.com/metatube-community/metatube-sdk-go/common/fetch"
 	"github.com/metatube-community/metatube-sdk-go/database"
+	"github.com/metatube-community/metatube-sdk-go/detector"
 	mt "github.com/metatube-community/metatube-sdk-go/provider"
 )
 
@@ -27,6 +30,8 @@ type Engine struct {
 	name    string
 	timeout time.Duration
 	fetcher *fetch.Fetcher
+	// Fetcher without request timeout for streaming.
+	streamFetcher *fetch.Fetcher
 	// Engine Logger
 	logger *log.Logger
 	// Name:Config Case-Insensitive Map
@@ -42,6 +47,15 @@ type Engine struct {
 	// E.g., github.com -> [Gfriends, ...]
 	actorHostProviders *maps.CaseInsensitiveMap[[]mt.ActorProvider]
 	movieHostProviders *maps.CaseInsensitiveMap[[]mt.MovieProvider]
+	// Name:Detector Case-Insensitive Map
+	// Face detectors overridden by provider configs.
+	providerDetectors *maps.CaseInsensitiveMap[detector.Detector]
+	// In-memory index of movie cover hashes.
+	coverHashes *coverHashIndex
+	// Selected best covers, keyed by provider ID.
+	bestCovers *ttlcache.Cache[string, *bestCoverChoice]
+	// Whether crop positions are being precomputed.
+	precomputing atomic.Bool
 }
 
 func New(db *gorm.DB, opts ...Option) *Engine {
@@ -56,6 +70,9 @@ func New(db *gorm.DB, opts ...Option) *Engine {
 		movieProviders:       maps.NewCaseInsensitiveMap[mt.MovieProvider](),
 		actorHostProviders:   maps.NewCaseInsensitiveMap[[]mt.ActorProvider](),
 		movieHostProviders:   maps.NewCaseInsensitiveMap[[]mt.MovieProvider](),
+		providerDetectors:    maps.NewCaseInsensitiveMap[detector.Detector](),
+		coverHashes:          newCoverHashIndex(),
+		bestCovers:           newBestCoverCache(),
 	}
 	// apply options.
 	for _, opt := range opts {
@@ -159,6 +176,15 @@ func (e *Engine) Fetch(url string, provider mt.Provider) (*http.Response, error)
 	return e.fetcher.Fetch(url)
 }
 
+// GetDetector returns the face detector configured for the
+// provider, or the default detector if none is configured.
+func (e *Engine) GetDetector(provider mt.Provider) detector.Detector {
+	if d, ok := e.providerDetectors.Get(provider.Name()); ok {
+		return d
+	}
+	return detector.Default()
+}
+
 // String returns the name of the Engine instance.
 func (e *Engine) String() string { return e.name }
 
@@ -0,0 +1,142 @@
+package engine
+
+import (
+	"github.com/lib/pq"
+	"gorm.io/gorm/clause"
+
+	"github.com/metatube-community/metatube-sdk-go/collection/sets"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	"github.com/metatube-community/metatube-sdk-go/translate"
+)
+
+// glossaryStoreBatchSize is the number of terms saved at once.
+const glossaryStoreBatchSize = 500
+
+var _ translate.GlossaryStore = (*glossaryStore)(nil)
+
+// glossaryStore stores glossary terms in the database.
+type glossaryStore struct {
+	e *Engine
+}
+
+// GlossaryStore returns a translate.GlossaryStore backed by the database.
+func (e *Engine) GlossaryStore() translate.GlossaryStore {
+	return &glossaryStore{e: e}
+}
+
+func (s *glossaryStore) Load() ([]*translate.Term, error) {
+	var rows []*model.GlossaryTerm
+	if err := s.e.db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	terms := make([]*translate.Term, 0, len(rows))
+	for _, row := range rows {
+		terms = append(terms, &translate.Term{
+			Term:        row.Term,
+			From:        row.SourceLang,
+			To:          row.TargetLang,
+			Translation: row.Translation,
+			Protected:   row.Protected,
+			Kind:        row.Kind,
+		})
+	}
+	return terms, nil
+}
+
+func (s *glossaryStore) Save(terms ...*translate.Term) error {
+	if len(terms) == 0 {
+		return nil
+	}
+	rows := make([]*model.GlossaryTerm, 0, len(terms))
+	for _, t := range terms {
+		rows = append(rows, glossaryTermRow(t))
+	}
+	return s.e.db.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).CreateInBatches(rows, glossaryStoreBatchSize).Error
+}
+
+func (s *glossaryStore) Delete(term *translate.Term) error {
+	row := glossaryTermRow(term)
+	return s.e.db.
+		Where("term = ?", row.Term).
+		Where("source_lang = ?", row.SourceLang).
+		Where("target_lang = ?", row.TargetLang).
+		Delete(&model.GlossaryTerm{}).Error
+}
+
+func glossaryTermRow(t *translate.Term) *model.GlossaryTerm {
+	t = t.Normalized()
+	return &model.GlossaryTerm{
+		Term:        t.Term,
+		SourceLang:  t.From,
+		TargetLang:  t.To,
+		Translation: t.Translation,
+		Protected:   t.Protected,
+		Kind:        t.Kind,
+	}
+}
+
+// GlossarySeedTerms collects known terms from the metadata in the database.
+// Actor names, aliases and makers are protected to be kept unchanged, while
+// genres are listed without translations, which can be filled in later.
+func (e *Engine) GlossarySeedTerms() ([]*translate.Term, error) {
+	var (
+		actors = sets.NewOrderedSet[string]()
+		makers = sets.NewOrderedSet[string]()
+		genres = sets.NewOrderedSet[string]()
+	)
+
+	var actorRows []struct {
+		Name    string
+		Aliases pq.StringArray `gorm:"type:text[]"`
+	}
+	if err := e.db.Model(&model.ActorInfo{}).
+		Select("name", "aliases").
+		Find(&actorRows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range actorRows {
+		actors.Add(row.Name)
+		actors.Add(row.Aliases...)
+	}
+
+	var movieRows []struct {
+		Actors pq.StringArray `gorm:"type:text[]"`
+		Maker  string
+		Genres pq.StringArray `gorm:"type:text[]"`
+	}
+	if err := e.db.Model(&model.MovieInfo{}).
+		Select("actors", "maker", "genres").
+		Find(&movieRows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range movieRows {
+		actors.Add(row.Actors...)
+		makers.Add(row.Maker)
+		genres.Add(row.Genres...)
+	}
+
+	var terms []*translate.Term
+	for _, v := range []struct {
+		set       *sets.OrderedSet[string, string]
+		kind      string
+		protected bool
+	}{
+		{actors, translate.ActorTerm, true},
+		{makers, translate.MakerTerm, true},
+		{genres, translate.GenreTerm, false},
+	} {
+		for _, term := range v.set.AsSlice() {
+			if term == "" {
+				continue
+			}
+			terms = append(terms, &translate.Term{
+				Term:      term,
+				Protected: v.protected,
+				Kind:      v.kind,
+			})
+		}
+	}
+	return terms, nil
+}
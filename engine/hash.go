@@ -0,0 +1,417 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/metatube-community/metatube-sdk-go/common/cluster"
+	"github.com/metatube-community/metatube-sdk-go/imageutil"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+const (
+	// maxCoverHashWorkers limits concurrent cover hashing on info saving.
+	maxCoverHashWorkers = 4
+	// maxCoverHashQueue limits the pending covers to hash, covers are
+	// dropped if the queue is full, and hashed by backfill later.
+	maxCoverHashQueue = 100
+	// maxCoverHashRows limits the number of hashes compared for duplicates.
+	maxCoverHashRows = 10000
+	// maxCoverHashQueryPairs limits the (provider, id) pairs per query,
+	// to stay within the bind variable limits of databases.
+	maxCoverHashQueryPairs = 400
+	// coverHashLoadRetry is the interval to retry a failed index loading.
+	coverHashLoadRetry = time.Minute
+)
+
+var (
+	ErrJobInProgress          = errors.New("job already in progress")
+	ErrCoverHashIndexNotReady = errors.New("cover hash index is still loading")
+)
+
+var _ cluster.Locatable[*coverHash, int] = (*coverHash)(nil)
+
+type coverHash model.MovieCoverHash
+
+func (h *coverHash) key() string {
+	return h.Provider + ":" + h.ID
+}
+
+func (h *coverHash) DistanceTo(o *coverHash) int {
+	return imageutil.HashDistance(uint64(h.Hash), uint64(o.Hash))
+}
+
+// coverHashIndex is an in-memory index of cover hashes, it's loaded from
+// the database in the background and kept updated as new covers are hashed.
+type coverHashIndex struct {
+	loadMu  sync.Mutex
+	loaded  atomic.Bool
+	triedAt time.Time // last loading attempt.
+	mu      sync.RWMutex
+	hashes  map[string]*coverHash
+	running atomic.Bool // backfilling.
+	// pending covers to hash, see saveMovieCoverHashAsync.
+	queue       chan *coverHashJob
+	startWorker sync.Once
+	// limits concurrent cover hashing of workers and backfill.
+	limiter chan struct{}
+}
+
+type coverHashJob struct {
+	provider mt.MovieProvider
+	info     *model.MovieInfo
+	img      image.Image
+}
+
+func newCoverHashIndex() *coverHashIndex {
+	return &coverHashIndex{
+		hashes:  make(map[string]*coverHash),
+		queue:   make(chan *coverHashJob, maxCoverHashQueue),
+		limiter: make(chan struct{}, maxCoverHashWorkers),
+	}
+}
+
+func (idx *coverHashIndex) get(provider, id string) (*coverHash, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	h, ok := idx.hashes[provider+":"+id]
+	return h, ok
+}
+
+func (idx *coverHashIndex) put(h *coverHash) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.hashes[h.key()] = h
+}
+
+func (idx *coverHashIndex) putIfAbsent(h *coverHash) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if _, ok := idx.hashes[h.key()]; !ok {
+		idx.hashes[h.key()] = h
+	}
+}
+
+func (idx *coverHashIndex) snapshot() []*coverHash {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	hashes := make([]*coverHash, 0, len(idx.hashes))
+	for _, h := range idx.hashes {
+		hashes = append(hashes, h)
+	}
+	return hashes
+}
+
+// MovieImageMatch is a movie matched by cover hash.
+type MovieImageMatch struct {
+	*model.MovieSearchResult
+	Distance int `json:"distance"`
+}
+
+// getCoverHashIndex returns the index without waiting for it to be loaded,
+// the loading is started in the background if not yet.
+func (e *Engine) getCoverHashIndex() *coverHashIndex {
+	if !e.coverHashes.loaded.Load() {
+		e.loadCoverHashIndexAsync()
+	}
+	return e.coverHashes
+}
+
+// loadCoverHashIndexAsync loads the index from database in the background,
+// unless it's loaded or being loaded, a failed loading is retried by later
+// calls after coverHashLoadRetry.
+func (e *Engine) loadCoverHashIndexAsync() {
+	idx := e.coverHashes
+	if idx.loaded.Load() || !idx.loadMu.TryLock() {
+		return
+	}
+	if idx.loaded.Load() || time.Since(idx.triedAt) < coverHashLoadRetry {
+		idx.loadMu.Unlock()
+		return
+	}
+	idx.triedAt = time.Now()
+	go func() {
+		defer idx.loadMu.Unlock()
+		hashes, err := e.loadMovieCoverHashes(-1)
+		if err != nil {
+			e.logger.Printf("Load cover hashes: %v", err)
+			return
+		}
+		for _, h := range hashes {
+			// hashes saved during the loading are newer.
+			idx.putIfAbsent(h)
+		}
+		idx.loaded.Store(true)
+		e.logger.Printf("Load %d cover hashes", len(hashes))
+	}()
+}
+
+func (e *Engine) loadMovieCoverHashes(limit int) ([]*coverHash, error) {
+	var hashes []*coverHash
+	err := e.db.Table(model.MovieCoverHashTableName).
+		Order("updated_at DESC").
+		Limit(limit).
+		Find(&hashes).Error
+	return hashes, err
+}
+
+// saveMovieCoverHashAsync queues the cover of the movie to be hashed and
+// saved in the background, it does nothing if the cover is already hashed,
+// and drops the cover if the queue is full.
+func (e *Engine) saveMovieCoverHashAsync(provider mt.MovieProvider, info *model.MovieInfo, img image.Image) {
+	idx := e.getCoverHashIndex()
+	if h, ok := idx.get(info.Provider, info.ID); ok && h.URL == preferredMovieImageURL(info, false) {
+		return // already hashed.
+	}
+	idx.startWorker.Do(func() {
+		for range maxCoverHashWorkers {
+			go e.coverHashWorker(idx)
+		}
+	})
+	select {
+	case idx.queue <- &coverHashJob{provider: provider, info: info, img: img}:
+	default: // hashed by backfill later.
+	}
+}
+
+func (e *Engine) coverHashWorker(idx *coverHashIndex) {
+	for job := range idx.queue {
+		idx.limiter <- struct{}{}
+		if err := e.saveMovieCoverHash(job.provider, job.info, job.img); err != nil {
+			e.logger.Printf("Save cover hash of %s:%s: %v", job.info.Provider, job.info.ID, err)
+		}
+		<-idx.limiter
+	}
+}
+
+// saveMovieCoverHash saves the hash of the preferred cover of the movie,
+// img is the fetched cover if available, otherwise it will be fetched.
+func (e *Engine) saveMovieCoverHash(provider mt.MovieProvider, info *model.MovieInfo, img image.Image) (err error) {
+	url := preferredMovieImageURL(info, false)
+	if url == "" {
+		return nil
+	}
+	if h, ok := e.getCoverHashIndex().get(info.Provider, info.ID); ok && h.URL == url {
+		return nil // already hashed.
+	}
+	if img == nil {
+		if img, err = e.getImageByURL(provider, url); err != nil {
+			return
+		}
+	}
+	hash, err := imageutil.PerceptionHash(img)
+	if err != nil {
+		return
+	}
+	h := &model.MovieCoverHash{
+		ID:       info.ID,
+		Provider: info.Provider,
+		URL:      url,
+		Hash:     int64(hash),
+	}
+	if err = e.db.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(h).Error; err != nil {
+		return
+	}
+	e.getCoverHashIndex().put((*coverHash)(h))
+	return
+}
+
+// BackfillMovieCoverHashes hashes the covers of at most limit movies in
+// the database that have not been hashed yet, and returns the number of
+// hashed covers. Only one backfill job is allowed to run at a time.
+func (e *Engine) BackfillMovieCoverHashes(limit int) (int, error) {
+	idx := e.getCoverHashIndex()
+	if !idx.running.CompareAndSwap(false, true) {
+		return 0, ErrJobInProgress
+	}
+	defer idx.running.Store(false)
+	return e.backfillMovieCoverHashes(idx, limit)
+}
+
+// StartBackfillMovieCoverHashes is like BackfillMovieCoverHashes,
+// but runs the backfill job in the background.
+func (e *Engine) StartBackfillMovieCoverHashes(limit int) error {
+	idx := e.getCoverHashIndex()
+	if !idx.running.CompareAndSwap(false, true) {
+		return ErrJobInProgress
+	}
+	go func() {
+		defer idx.running.Store(false)
+		if _, err := e.backfillMovieCoverHashes(idx, limit); err != nil {
+			e.logger.Printf("Backfill cover hashes: %v", err)
+		}
+	}()
+	return nil
+}
+
+func (e *Engine) backfillMovieCoverHashes(idx *coverHashIndex, limit int) (int, error) {
+	var infos []*model.MovieInfo
+	if err := e.db.Model(&model.MovieInfo{}).
+		Joins(fmt.Sprintf("LEFT JOIN %[1]s h ON h.provider = %[2]s.provider AND h.id = %[2]s.id",
+			model.MovieCoverHashTableName, model.MovieMetadataTableName)).
+		Where("h.id IS NULL").
+		Limit(limit).
+		Find(&infos).Error; err != nil {
+		return 0, err
+	}
+
+	var (
+		wg    sync.WaitGroup
+		count atomic.Int64
+	)
+	for _, info := range infos {
+		provider, err := e.GetMovieProviderByName(info.Provider)
+		if err != nil {
+			continue
+		}
+		idx.limiter <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() { <-idx.limiter; wg.Done() }()
+			if err := e.saveMovieCoverHash(provider, info, nil); err != nil {
+				e.logger.Printf("Backfill cover hash of %s:%s: %v", info.Provider, info.ID, err)
+				return
+			}
+			count.Add(1)
+		}()
+	}
+	wg.Wait()
+	e.logger.Printf("Backfill %d/%d cover hashes", count.Load(), len(infos))
+	return int(count.Load()), nil
+}
+
+func (e *Engine) getMovieSearchResults(hashes []*coverHash) ([]*model.MovieSearchResult, error) {
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+	var infos []*model.MovieInfo
+	for chunk := range slices.Chunk(hashes, maxCoverHashQueryPairs) {
+		pairs := make([][]any, 0, len(chunk))
+		for _, h := range chunk {
+			pairs = append(pairs, []any{h.Provider, h.ID})
+		}
+		var found []*model.MovieInfo
+		if err := e.db.Where("(provider, id) IN ?", pairs).Find(&found).Error; err != nil {
+			return nil, err
+		}
+		infos = append(infos, found...)
+	}
+	// keep the order of the given hashes.
+	index := make(map[string]*model.MovieInfo, len(infos))
+	for _, info := range infos {
+		index[info.Provider+":"+info.ID] = info
+	}
+	results := make([]*model.MovieSearchResult, 0, len(hashes))
+	for _, h := range hashes {
+		if info, ok := index[h.key()]; ok && info.IsValid() {
+			results = append(results, info.ToSearchResult())
+		}
+	}
+	return results, nil
+}
+
+// DuplicateMovies are groups of movies with similar covers.
+type DuplicateMovies struct {
+	Groups [][]*model.MovieSearchResult `json:"groups"`
+	// Truncated is true if only the most recent maxCoverHashRows
+	// hashes are compared, i.e., some duplicates may be missing.
+	Truncated bool `json:"truncated"`
+}
+
+// GetDuplicateMovies groups movies in the database by the similarity
+// of their cover hashes, only groups with duplicates are returned.
+func (e *Engine) GetDuplicateMovies() (*DuplicateMovies, error) {
+	// load one more row to tell whether the hashes are truncated.
+	hashes, err := e.loadMovieCoverHashes(maxCoverHashRows + 1)
+	if err != nil {
+		return nil, err
+	}
+	duplicates := &DuplicateMovies{
+		Groups:    [][]*model.MovieSearchResult{},
+		Truncated: len(hashes) > maxCoverHashRows,
+	}
+	if duplicates.Truncated {
+		hashes = hashes[:maxCoverHashRows]
+	}
+	groups := cluster.GroupByDistance(hashes, imageutil.SimilarHashDistance)
+	cluster.SortGroupsBySize(groups)
+
+	for _, group := range groups {
+		if len(group.Items) < 2 {
+			break // sorted by size.
+		}
+		results, err := e.getMovieSearchResults(group.Items)
+		if err != nil {
+			return nil, err
+		}
+		if len(results) > 1 {
+			duplicates.Groups = append(duplicates.Groups, results)
+		}
+	}
+	return duplicates, nil
+}
+
+// SearchMovieByImage finds at most limit movies whose cover hash is within
+// maxDistance to the hash of the given image, ranked by the hash distance.
+// ErrCoverHashIndexNotReady is returned while the index is being loaded.
+func (e *Engine) SearchMovieByImage(img image.Image, maxDistance, limit int) ([]*MovieImageMatch, error) {
+	idx := e.getCoverHashIndex()
+	if !idx.loaded.Load() {
+		return nil, ErrCoverHashIndexNotReady
+	}
+	hash, err := imageutil.PerceptionHash(img)
+	if err != nil {
+		return nil, err
+	}
+	type candidate struct {
+		hash     *coverHash
+		distance int
+	}
+	var candidates []candidate
+	for _, h := range idx.snapshot() {
+		if d := imageutil.HashDistance(hash, uint64(h.Hash)); d <= maxDistance {
+			candidates = append(candidates, candidate{hash: h, distance: d})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].hash.key() < candidates[j].hash.key()
+	})
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	var (
+		hashes    = make([]*coverHash, 0, len(candidates))
+		distances = make(map[string]int, len(candidates))
+	)
+	for _, c := range candidates {
+		hashes = append(hashes, c.hash)
+		distances[c.hash.key()] = c.distance
+	}
+	results, err := e.getMovieSearchResults(hashes)
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]*MovieImageMatch, 0, len(results))
+	for _, result := range results {
+		matches = append(matches, &MovieImageMatch{
+			MovieSearchResult: result,
+			Distance:          distances[result.Provider+":"+result.ID],
+		})
+	}
+	return matches, nil
+}
@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"image"
+
+	"github.com/lib/pq"
+
+	"github.com/metatube-community/metatube-sdk-go/detector"
+	"github.com/metatube-community/metatube-sdk-go/engine/providerid"
+	"github.com/metatube-community/metatube-sdk-go/imageutil"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+const (
+	// headshotScale is the size of headshots relative to the face.
+	headshotScale = 2.5
+	// maxHeadshotMovies limits the movies to try for an actor.
+	maxHeadshotMovies = 5
+)
+
+// GetActorImageFromMovie crops a square headshot of the only credited
+// actor from the cover of the movie.
+func (e *Engine) GetActorImageFromMovie(pid providerid.ProviderID) (image.Image, error) {
+	info, err := e.GetMovieInfoByProviderID(pid, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(info.Actors) != 1 {
+		// faces cannot be matched to actors otherwise.
+		return nil, mt.ErrImageNotFound
+	}
+	return e.getHeadshotFromMovie(e.MustGetMovieProviderByName(pid.Provider), info)
+}
+
+func (e *Engine) getHeadshotFromMovie(provider mt.MovieProvider, info *model.MovieInfo) (image.Image, error) {
+	img, err := e.getImageByURL(provider, preferredMovieImageURL(info, false))
+	if err != nil {
+		return nil, err
+	}
+	face, found := detector.FindPrimaryFace(e.GetDetector(provider), img, true)
+	if !found {
+		return nil, mt.ErrImageNotFound
+	}
+	return imageutil.CropImage(img,
+		imageutil.SquareRectAround(img.Bounds(), face, headshotScale)), nil
+}
+
+// getActorImageFromDBMovies crops a headshot from the recent movies in
+// the database, in which the actor is the only credited one.
+func (e *Engine) getActorImageFromDBMovies(name string) (image.Image, error) {
+	var infos []*model.MovieInfo
+	if err := e.db.
+		Where("actors = ?", pq.StringArray{name}).
+		Order("updated_at DESC").
+		Limit(maxHeadshotMovies).
+		Find(&infos).Error; err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		provider, err := e.GetMovieProviderByName(info.Provider)
+		if err != nil {
+			continue
+		}
+		if img, err := e.getHeadshotFromMovie(provider, info); err == nil {
+			return img, nil
+		}
+	}
+	return nil, mt.ErrImageNotFound
+}
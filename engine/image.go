@@ -4,6 +4,7 @@ import (
 	"image"
 
 	"github.com/metatube-community/metatube-sdk-go/common/number"
+	"github.com/metatube-community/metatube-sdk-go/common/parallel"
 	R "github.com/metatube-community/metatube-sdk-go/constant"
 	"github.com/metatube-community/metatube-sdk-go/detector"
 	"github.com/metatube-community/metatube-sdk-go/engine/providerid"
@@ -20,13 +21,70 @@ const (
 	defaultMovieBackdropImagePosition = 0.0
 )
 
+// CropMode determines how the crop position of an image is chosen.
+type CropMode uint8
+
+const (
+	// FixedCrop crops at the given position.
+	FixedCrop CropMode = iota
+	// FaceCrop centers the primary face, falling back to
+	// the most salient window, then the given position.
+	FaceCrop
+	// SaliencyCrop centers the most salient window, falling
+	// back to the given position.
+	SaliencyCrop
+)
+
+// ImageOption configures how images are selected and processed.
+type ImageOption func(*imageOptions)
+
+type imageOptions struct {
+	trim bool
+	best bool
+	crop *CropMode
+}
+
+func newImageOptions(opts ...ImageOption) *imageOptions {
+	o := &imageOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithBorderTrim removes uniform-color borders, e.g., letterbox bars,
+// before cropping.
+func WithBorderTrim(v bool) ImageOption {
+	return func(o *imageOptions) {
+		o.trim = v
+	}
+}
+
+// WithBestCover picks the highest-resolution version of the movie
+// cover among all providers that have the same movie.
+func WithBestCover(v bool) ImageOption {
+	return func(o *imageOptions) {
+		o.best = v
+	}
+}
+
+// WithCropMode overrides the crop mode that GetImageByURL derives from
+// its auto parameter, and GetMoviePrimaryImage from the movie number,
+// e.g., to use SaliencyCrop.
+func WithCropMode(mode CropMode) ImageOption {
+	return func(o *imageOptions) {
+		o.crop = &mode
+	}
+}
+
 func (e *Engine) GetActorPrimaryImage(pid providerid.ProviderID) (image.Image, error) {
 	info, err := e.GetActorInfoByProviderID(pid, true)
 	if err != nil {
 		return nil, err
 	}
 	if len(info.Images) == 0 {
-		return nil, mt.ErrImageNotFound
+		// fallback to headshots cropped from movie covers.
+		return e.getActorImageFromDBMovies(info.Name)
 	}
 	return e.GetImageByURL(
 		e.MustGetActorProviderByName(pid.Provider), info.Images[0],
@@ -34,60 +92,155 @@ func (e *Engine) GetActorPrimaryImage(pid providerid.ProviderID) (image.Image, e
 	)
 }
 
-func (e *Engine) GetMoviePrimaryImage(pid providerid.ProviderID, ratio, pos float64) (image.Image, error) {
-	url, info, err := e.getPreferredMovieImageURLAndInfo(pid, true)
+func (e *Engine) GetMoviePrimaryImage(pid providerid.ProviderID, ratio, pos float64, opts ...ImageOption) (image.Image, error) {
+	o := newImageOptions(opts...)
+	provider, img, info, err := e.getMovieImage(pid, true, o)
 	if err != nil {
 		return nil, err
 	}
 	if ratio < 0 /* default primary ratio */ {
 		ratio = R.PrimaryImageRatio
 	}
-	var auto bool
+	mode := FixedCrop
 	if pos < 0 /* manual position disabled */ {
 		pos = defaultMoviePrimaryImagePosition
-		auto = number.RequiresFaceDetection(info.Number)
+		if number.RequiresFaceDetection(info.Number) {
+			mode = FaceCrop
+		}
 	}
-	return e.GetImageByURL(
-		e.MustGetMovieProviderByName(pid.Provider),
-		url, ratio, pos, auto,
-	)
+	if o.crop != nil {
+		mode = *o.crop
+	}
+	if mode == FaceCrop && !o.best /* cached by the movie's own image */ &&
+		ratio == R.PrimaryImageRatio /* other ratios are not worth caching */ {
+		return e.cropMoviePrimaryImage(provider, info, img, pos, o), nil
+	}
+	return e.cropImage(provider, img, ratio, pos, mode, o), nil
 }
 
-func (e *Engine) GetMovieThumbImage(pid providerid.ProviderID) (image.Image, error) {
-	url, _, err := e.getPreferredMovieImageURLAndInfo(pid, false)
+func (e *Engine) GetMovieThumbImage(pid providerid.ProviderID, opts ...ImageOption) (image.Image, error) {
+	o := newImageOptions(opts...)
+	provider, img, _, err := e.getMovieImage(pid, false, o)
 	if err != nil {
 		return nil, err
 	}
-	return e.GetImageByURL(
-		e.MustGetMovieProviderByName(pid.Provider), url,
-		R.ThumbImageRatio, defaultMovieThumbImagePosition, false,
-	)
+	return e.cropImage(provider, img,
+		R.ThumbImageRatio, defaultMovieThumbImagePosition, FixedCrop, o), nil
 }
 
-func (e *Engine) GetMovieBackdropImage(pid providerid.ProviderID) (image.Image, error) {
-	url, _, err := e.getPreferredMovieImageURLAndInfo(pid, false)
+func (e *Engine) GetMovieBackdropImage(pid providerid.ProviderID, opts ...ImageOption) (image.Image, error) {
+	o := newImageOptions(opts...)
+	provider, img, _, err := e.getMovieImage(pid, false, o)
 	if err != nil {
 		return nil, err
 	}
-	return e.GetImageByURL(
-		e.MustGetMovieProviderByName(pid.Provider), url,
-		R.BackdropImageRatio, defaultMovieBackdropImagePosition, false,
+	return e.cropImage(provider, img,
+		R.BackdropImageRatio, defaultMovieBackdropImagePosition, FixedCrop, o), nil
+}
+
+func (e *Engine) GetMoviePreviewImage(pid providerid.ProviderID, index int) (image.Image, error) {
+	info, err := e.GetMovieInfoByProviderID(pid, true)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(info.PreviewImages) {
+		return nil, mt.ErrImageNotFound
+	}
+	return e.getImageByURL(e.MustGetMovieProviderByName(pid.Provider), info.PreviewImages[index])
+}
+
+// GetMoviePreviewSprite composes the preview images of the movie into a grid
+// contact sheet, the tile height is derived from the first preview image.
+func (e *Engine) GetMoviePreviewSprite(pid providerid.ProviderID, columns, tileWidth int) (image.Image, error) {
+	const (
+		maxPreviewImages = 30
+		// fetch a few images at a time to avoid being banned.
+		maxPreviewFetches = 4
 	)
+	info, err := e.GetMovieInfoByProviderID(pid, true)
+	if err != nil {
+		return nil, err
+	}
+	urls := info.PreviewImages
+	if len(urls) == 0 {
+		return nil, mt.ErrImageNotFound
+	}
+	if len(urls) > maxPreviewImages {
+		urls = urls[:maxPreviewImages]
+	}
+	provider := e.MustGetMovieProviderByName(pid.Provider)
+	var images []image.Image
+	for i, img := range parallel.ParallelN(maxPreviewFetches, func(url string) image.Image {
+		img, err := e.getImageByURL(provider, url)
+		if err != nil {
+			return nil
+		}
+		return img
+	}, urls...) {
+		if img == nil {
+			e.logger.Printf("Skip preview image: %s", urls[i])
+			continue
+		}
+		images = append(images, img)
+	}
+	if len(images) == 0 {
+		return nil, mt.ErrImageNotFound
+	}
+	size := images[0].Bounds().Size()
+	tileHeight := max(tileWidth*size.Y/max(size.X, 1), 1)
+	return imageutil.Sprite(images, columns, tileWidth, tileHeight), nil
 }
 
-func (e *Engine) GetImageByURL(provider mt.Provider, url string, ratio, pos float64, auto bool) (img image.Image, err error) {
+// GetImageByURL crops the image at the position, or centers the primary
+// face if auto is true, the crop mode can be overridden by WithCropMode.
+func (e *Engine) GetImageByURL(provider mt.Provider, url string, ratio, pos float64, auto bool, opts ...ImageOption) (img image.Image, err error) {
 	if img, err = e.getImageByURL(provider, url); err != nil {
 		return
 	}
+	o := newImageOptions(opts...)
+	mode := FixedCrop
 	if auto {
+		mode = FaceCrop
+	}
+	if o.crop != nil {
+		mode = *o.crop
+	}
+	return e.cropImage(provider, img, ratio, pos, mode, o), nil
+}
+
+func (e *Engine) cropImage(provider mt.Provider, img image.Image, ratio, pos float64, mode CropMode, o *imageOptions) image.Image {
+	if o.trim {
+		img = imageutil.TrimBorders(img)
+	}
+	pos, _ = e.findCropPosition(provider, img, ratio, pos, mode)
+	return imageutil.CropImagePosition(img, ratio, pos)
+}
+
+// Methods to find the crop position.
+const (
+	fixedCropMethod    = "fixed"
+	faceCropMethod     = "face"
+	saliencyCropMethod = "saliency"
+)
+
+// findCropPosition returns the crop position of the image according to
+// the crop mode, along with the method that finds it.
+func (e *Engine) findCropPosition(provider mt.Provider, img image.Image, ratio, pos float64, mode CropMode) (float64, string) {
+	if mode == FaceCrop {
 		// only turn on advanced for movie providers.
 		advancedMode := e.IsMovieProvider(provider.Name())
-		axisR, found := detector.FindPrimaryFaceAxisRatio(img, ratio, advancedMode)
-		if found {
-			pos = axisR // override the default position with detected position.
+		if axisR, found := detector.FindPrimaryFaceAxisRatioWithDetector(
+			e.GetDetector(provider), img, ratio, advancedMode); found {
+			return axisR, faceCropMethod
 		}
 	}
-	return imageutil.CropImagePosition(img, ratio, pos), nil
+	if mode != FixedCrop {
+		// second stage: the most interesting part of the image.
+		if axisR, ok := detector.FindSalientAxisRatio(img, ratio); ok {
+			return axisR, saliencyCropMethod
+		}
+	}
+	return pos, fixedCropMethod
 }
 
 func (e *Engine) getImageByURL(provider mt.Provider, url string) (img image.Image, err error) {
@@ -100,11 +253,36 @@ func (e *Engine) getImageByURL(provider mt.Provider, url string) (img image.Imag
 	return
 }
 
+// getMovieImage fetches the preferred movie image, or the best cover
+// among all providers if requested, along with its provider.
+func (e *Engine) getMovieImage(pid providerid.ProviderID, thumb bool, o *imageOptions) (provider mt.MovieProvider, img image.Image, info *model.MovieInfo, err error) {
+	url, info, err := e.getPreferredMovieImageURLAndInfo(pid, thumb)
+	if err != nil {
+		return
+	}
+	provider = e.MustGetMovieProviderByName(pid.Provider)
+	if o.best {
+		if cover := e.getBestMovieCover(info, thumb); cover != nil {
+			return cover.provider, cover.img, info, nil
+		}
+	}
+	if img, err = e.getImageByURL(provider, url); err == nil && !thumb {
+		// index the fetched cover for reverse image search.
+		e.saveMovieCoverHashAsync(provider, info, img)
+	}
+	return
+}
+
 func (e *Engine) getPreferredMovieImageURLAndInfo(pid providerid.ProviderID, thumb bool) (url string, info *model.MovieInfo, err error) {
 	info, err = e.GetMovieInfoByProviderID(pid, true)
 	if err != nil {
 		return
 	}
+	url = preferredMovieImageURL(info, thumb)
+	return
+}
+
+func preferredMovieImageURL(info *model.MovieInfo, thumb bool) (url string) {
 	url = info.CoverURL
 	if thumb && info.BigThumbURL != "" /* big thumb > cover */ {
 		url = info.BigThumbURL
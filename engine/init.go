@@ -2,9 +2,11 @@ package engine
 
 import (
 	"log"
+	"net/http"
 	"os"
 
 	"github.com/metatube-community/metatube-sdk-go/common/fetch"
+	"github.com/metatube-community/metatube-sdk-go/detector"
 	mt "github.com/metatube-community/metatube-sdk-go/provider"
 )
 
@@ -22,6 +24,11 @@ func (e *Engine) initLogger() {
 
 func (e *Engine) initFetcher() {
 	e.fetcher = fetch.Default(&fetch.Config{Timeout: e.timeout})
+	// streams are bounded by contexts instead of a total timeout,
+	// but upstreams that hang before responding are given up.
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.ResponseHeaderTimeout = e.timeout
+	e.streamFetcher = fetch.Default(&fetch.Config{Transport: t})
 }
 
 // initActorProviders initializes actor providers.
@@ -85,6 +92,7 @@ func (e *Engine) applyProviderConfig(providerType string, provider mt.Provider,
 		proxyConfigKey    = "proxy"
 		priorityConfigKey = "priority"
 		timeoutConfigKey  = "timeout"
+		detectorConfigKey = "detector"
 	)
 
 	// Apply overridden priority.
@@ -113,6 +121,18 @@ func (e *Engine) applyProviderConfig(providerType string, provider mt.Provider,
 		}
 	}
 
+	// Apply face detector backend.
+	if config.Has(detectorConfigKey) {
+		if v, err := config.GetString(detectorConfigKey); err == nil {
+			d, ok := detector.Lookup(v)
+			if !ok {
+				e.logger.Fatalf("Unknown face detector for %s provider '%s': %s", providerType, provider.Name(), v)
+			}
+			e.logger.Printf("Override %s provider face detector: %s=%s", providerType, provider.Name(), v)
+			e.providerDetectors.Set(provider.Name(), d)
+		}
+	}
+
 	// Apply full config.
 	if s, ok := provider.(mt.ConfigSetter); ok {
 		if err := s.SetConfig(config); err != nil {
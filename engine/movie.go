@@ -88,6 +88,17 @@ func (e *Engine) SearchMovie(keyword, name string, fallback bool) ([]*model.Movi
 }
 
 func (e *Engine) searchMovieAll(keyword string) (results []*model.MovieSearchResult, err error) {
+	// only search the providers that the keyword is routed to.
+	route := e.routeMovieSearch(keyword)
+	if results, err = e.searchMovieProviders(keyword, route.Providers); err == nil &&
+		len(results) == 0 && len(route.Fallback) > 0 {
+		// fan out to the rest if the routed providers found nothing.
+		results, err = e.searchMovieProviders(keyword, route.Fallback)
+	}
+	return
+}
+
+func (e *Engine) searchMovieProviders(keyword string, providers []*MovieSearchRouteProvider) (results []*model.MovieSearchResult, err error) {
 	type response struct {
 		Results   []*model.MovieSearchResult
 		Error     error
@@ -98,7 +109,7 @@ func (e *Engine) searchMovieAll(keyword string) (results []*model.MovieSearchRes
 	respCh := make(chan response)
 
 	var wg sync.WaitGroup
-	for _, provider := range e.movieProviders.Iterator() {
+	for _, p := range providers {
 		wg.Add(1)
 		// Goroutine started time.
 		startTime := time.Now()
@@ -113,7 +124,7 @@ func (e *Engine) searchMovieAll(keyword string) (results []*model.MovieSearchRes
 				StartTime: startTime,
 				EndTime:   time.Now(),
 			}
-		}(provider)
+		}(p.provider)
 	}
 	go func() {
 		wg.Wait()
@@ -121,7 +132,7 @@ func (e *Engine) searchMovieAll(keyword string) (results []*model.MovieSearchRes
 		close(respCh)
 	}()
 
-	ds := make([]string, 0, e.movieProviders.Len())
+	ds := make([]string, 0, len(providers))
 	// response channel.
 	for resp := range respCh {
 		ds = append(ds, func(a, b, c any) string {
@@ -145,7 +156,9 @@ func (e *Engine) searchMovieAll(keyword string) (results []*model.MovieSearchRes
 	return
 }
 
-// SearchMovieAll searches the keyword from all providers.
+// SearchMovieAll searches the keyword from all providers, or only from
+// the providers that the keyword is routed to, see RouteMovieSearch, and
+// from the rest if those find nothing.
 func (e *Engine) SearchMovieAll(keyword string, fallback bool) (results []*model.MovieSearchResult, err error) {
 	if keyword = number.Trim(keyword); keyword == "" {
 		return nil, mt.ErrInvalidKeyword
@@ -223,9 +236,11 @@ func (e *Engine) getMovieInfoWithCallback(provider mt.MovieProvider, id string,
 	// delayed info auto-save.
 	defer func() {
 		if err == nil && info.IsValid() {
-			e.db.Clauses(clause.OnConflict{
+			if e.db.Clauses(clause.OnConflict{
 				UpdateAll: true,
-			}).Create(info) // ignore error
+			}).Create(info).Error == nil {
+				e.saveMovieCoverHashAsync(provider, info, nil)
+			}
 		}
 	}()
 	return callback()
@@ -0,0 +1,152 @@
+package engine
+
+import (
+	"image"
+	"sync"
+	"sync/atomic"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	R "github.com/metatube-community/metatube-sdk-go/constant"
+	"github.com/metatube-community/metatube-sdk-go/imageutil"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+const (
+	// maxCropPositionWorkers limits concurrent face detections
+	// of the precomputation, since each one is already parallel.
+	maxCropPositionWorkers = 2
+	// cropPositionBatchSize is the number of movies loaded at once.
+	cropPositionBatchSize = 100
+)
+
+// cropMoviePrimaryImage crops the primary image of the movie to the
+// default primary ratio at the auto-detected position, which is cached
+// in the database. Only the default ratio is cached, since arbitrary
+// ratios from queries would grow the cache without bound.
+func (e *Engine) cropMoviePrimaryImage(provider mt.MovieProvider, info *model.MovieInfo, img image.Image, pos float64, o *imageOptions) image.Image {
+	const ratio = R.PrimaryImageRatio
+	if o.trim {
+		img = imageutil.TrimBorders(img)
+	}
+	if cached, ok := e.getMovieCropPosition(info, ratio, o.trim); ok {
+		if cached.Method != fixedCropMethod {
+			pos = cached.Position
+		}
+	} else {
+		pos = e.saveMovieCropPosition(provider, info, img, ratio, pos, o.trim)
+	}
+	return imageutil.CropImagePosition(img, ratio, pos)
+}
+
+// getMovieCropPosition returns the cached crop position of the primary
+// image of the movie, it's invalid if the image URL has changed.
+func (e *Engine) getMovieCropPosition(info *model.MovieInfo, ratio float64, trimmed bool) (*model.MovieCropPosition, bool) {
+	cached := &model.MovieCropPosition{}
+	if err := e.db.
+		Where("provider = ?", info.Provider).
+		Where("id = ?", info.ID).
+		Where("ratio = ?", ratio).
+		Where("trimmed = ?", trimmed).
+		First(cached).Error; err != nil {
+		return nil, false
+	}
+	return cached, cached.URL == preferredMovieImageURL(info, true)
+}
+
+// saveMovieCropPosition detects the crop position of the image and saves
+// it, pos is returned if no position can be detected.
+func (e *Engine) saveMovieCropPosition(provider mt.MovieProvider, info *model.MovieInfo, img image.Image, ratio, pos float64, trimmed bool) float64 {
+	pos, method := e.findCropPosition(provider, img, ratio, pos, FaceCrop)
+	e.db.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(&model.MovieCropPosition{
+		ID:       info.ID,
+		Provider: info.Provider,
+		Ratio:    ratio,
+		Trimmed:  trimmed,
+		URL:      preferredMovieImageURL(info, true),
+		Method:   method,
+		Position: pos,
+	}) // ignore error
+	return pos
+}
+
+// PrecomputeMovieCropPositions detects and caches the default primary
+// image crop positions of all movies in the database that require face
+// detection, and returns the number of computed positions.
+func (e *Engine) PrecomputeMovieCropPositions() (int, error) {
+	if !e.precomputing.CompareAndSwap(false, true) {
+		return 0, ErrJobInProgress
+	}
+	defer e.precomputing.Store(false)
+	return e.precomputeMovieCropPositions()
+}
+
+// StartPrecomputeMovieCropPositions is like PrecomputeMovieCropPositions,
+// but runs the precomputation in the background.
+func (e *Engine) StartPrecomputeMovieCropPositions() error {
+	if !e.precomputing.CompareAndSwap(false, true) {
+		return ErrJobInProgress
+	}
+	go func() {
+		defer e.precomputing.Store(false)
+		if _, err := e.precomputeMovieCropPositions(); err != nil {
+			e.logger.Printf("Precompute crop positions: %v", err)
+		}
+	}()
+	return nil
+}
+
+func (e *Engine) precomputeMovieCropPositions() (int, error) {
+	var (
+		wg      sync.WaitGroup
+		count   atomic.Int64
+		limiter = make(chan struct{}, maxCropPositionWorkers)
+	)
+	defer wg.Wait()
+
+	for offset := 0; ; offset += cropPositionBatchSize {
+		var infos []*model.MovieInfo
+		if err := e.db.
+			Order("provider").Order("id").
+			Offset(offset).
+			Limit(cropPositionBatchSize).
+			Find(&infos).Error; err != nil {
+			return int(count.Load()), err
+		}
+		for _, info := range infos {
+			if !number.RequiresFaceDetection(info.Number) {
+				continue
+			}
+			if _, ok := e.getMovieCropPosition(info, R.PrimaryImageRatio, false); ok {
+				continue // already computed.
+			}
+			provider, err := e.GetMovieProviderByName(info.Provider)
+			if err != nil {
+				continue
+			}
+			limiter <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer func() { <-limiter; wg.Done() }()
+				img, err := e.getImageByURL(provider, preferredMovieImageURL(info, true))
+				if err != nil {
+					e.logger.Printf("Precompute crop position of %s:%s: %v", info.Provider, info.ID, err)
+					return
+				}
+				e.saveMovieCropPosition(provider, info, img,
+					R.PrimaryImageRatio, defaultMoviePrimaryImagePosition, false)
+				count.Add(1)
+			}()
+		}
+		if len(infos) < cropPositionBatchSize {
+			break
+		}
+	}
+	wg.Wait()
+	e.logger.Printf("Precompute %d crop positions", count.Load())
+	return int(count.Load()), nil
+}
@@ -0,0 +1,93 @@
+package engine
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// MovieSearchRoute is the decision of which providers a movie search
+// is sent to.
+type MovieSearchRoute struct {
+	Keyword string `json:"keyword"`
+	// Routed is true if the keyword matches the number patterns of some
+	// providers, otherwise the search fans out to all providers.
+	Routed    bool                        `json:"routed"`
+	Providers []*MovieSearchRouteProvider `json:"providers"`
+	// Fallback is the rest of providers, which the search fans out to
+	// if the routed providers find nothing.
+	Fallback []*MovieSearchRouteProvider `json:"fallback,omitempty"`
+}
+
+// MovieSearchRouteProvider is a provider that a movie search is sent to.
+type MovieSearchRouteProvider struct {
+	Name string `json:"name"`
+	// Pattern is the matched number pattern, empty if not routed, or
+	// the provider has no patterns but accepts the keyword.
+	Pattern string `json:"pattern,omitempty"`
+
+	provider mt.MovieProvider
+}
+
+func (e *Engine) routeMovieSearch(keyword string) *MovieSearchRoute {
+	var routed, accepted, all []*MovieSearchRouteProvider
+	for _, provider := range e.movieProviders.Iterator() {
+		all = append(all, &MovieSearchRouteProvider{Name: provider.Name(), provider: provider})
+		if router, ok := provider.(mt.MovieNumberRouter); ok {
+			for _, pattern := range router.MovieNumberPatterns() {
+				if pattern.MatchString(keyword) {
+					routed = append(routed, &MovieSearchRouteProvider{
+						Name:     provider.Name(),
+						Pattern:  pattern.String(),
+						provider: provider,
+					})
+					break
+				}
+			}
+			continue
+		}
+		// aggregators without patterns, e.g., JavBus, are searched
+		// along with the routed providers if they accept the keyword.
+		if searcher, ok := provider.(mt.MovieSearcher); ok &&
+			searcher.NormalizeMovieKeyword(keyword) != "" {
+			accepted = append(accepted, all[len(all)-1])
+		}
+	}
+	route := &MovieSearchRoute{
+		Keyword:   keyword,
+		Routed:    len(routed) > 0,
+		Providers: all,
+	}
+	if route.Routed {
+		route.Providers = append(routed, accepted...)
+		for _, p := range all {
+			if !slices.ContainsFunc(route.Providers, func(r *MovieSearchRouteProvider) bool {
+				return r.provider == p.provider
+			}) {
+				route.Fallback = append(route.Fallback, p)
+			}
+		}
+	}
+	sortMovieSearchRouteProviders(route.Providers)
+	sortMovieSearchRouteProviders(route.Fallback)
+	return route
+}
+
+func sortMovieSearchRouteProviders(providers []*MovieSearchRouteProvider) {
+	slices.SortFunc(providers, func(a, b *MovieSearchRouteProvider) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+}
+
+// RouteMovieSearch returns the providers that SearchMovieAll sends the
+// keyword to, i.e., providers whose number patterns match the keyword
+// along with the providers without patterns that accept the keyword,
+// or all providers if no pattern matches.
+func (e *Engine) RouteMovieSearch(keyword string) (*MovieSearchRoute, error) {
+	if keyword = number.Trim(keyword); keyword == "" {
+		return nil, mt.ErrInvalidKeyword
+	}
+	return e.routeMovieSearch(keyword), nil
+}
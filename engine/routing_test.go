@@ -0,0 +1,50 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteMovieSearch(t *testing.T) {
+	e := Default()
+	for _, unit := range []struct {
+		keyword  string
+		routed   bool
+		includes []string
+		excludes []string
+	}{
+		{"FC2-123456", true, []string{"FC2", "fc2hub", "FC2PPVDB", "JAVFREE"}, []string{"JavBus", "FANZA"}},
+		{"HEYZO-1234", true, []string{"HEYZO", "JavBus"}, []string{"JAVFREE", "FANZA"}},
+		{"123456-789", true, []string{"1Pondo", "Caribbeancom", "JavBus"}, []string{"JAVFREE", "FANZA"}},
+		{"n1234", true, []string{"TOKYO-HOT", "JavBus"}, []string{"JAVFREE", "FANZA"}},
+		{"ABP-030", false, []string{"FANZA", "JavBus", "HEYZO"}, nil},
+	} {
+		route, err := e.RouteMovieSearch(unit.keyword)
+		if !assert.NoError(t, err, unit.keyword) {
+			continue
+		}
+		assert.Equal(t, unit.routed, route.Routed, unit.keyword)
+
+		names := func(providers []*MovieSearchRouteProvider) (names []string) {
+			for _, p := range providers {
+				names = append(names, p.Name)
+			}
+			return
+		}
+		providers, fallback := names(route.Providers), names(route.Fallback)
+		assert.Subset(t, providers, unit.includes, unit.keyword)
+		for _, name := range unit.excludes {
+			assert.NotContains(t, providers, name, unit.keyword)
+			// but searched if the routed providers find nothing.
+			assert.Contains(t, fallback, name, unit.keyword)
+		}
+		assert.Len(t, append(providers, fallback...), e.movieProviders.Len(), unit.keyword)
+		if !unit.routed {
+			assert.Empty(t, fallback, unit.keyword)
+		}
+	}
+
+	_, err := e.RouteMovieSearch("  ")
+	assert.Error(t, err)
+}
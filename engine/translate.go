@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+	"github.com/metatube-community/metatube-sdk-go/translate"
+)
+
+var _ translate.CacheStore = (*translationCacheStore)(nil)
+
+// translationCacheStore stores translations in the database.
+type translationCacheStore struct {
+	e *Engine
+}
+
+// TranslationCacheStore returns a translate.CacheStore backed by the database.
+func (e *Engine) TranslationCacheStore() translate.CacheStore {
+	return &translationCacheStore{e: e}
+}
+
+func (s *translationCacheStore) Get(key translate.CacheKey) (string, bool) {
+	cache := &model.TranslationCache{}
+	if err := s.e.db.
+		Where("hash = ?", key.Hash()).
+		First(cache).Error; err != nil {
+		return "", false
+	}
+	return cache.Text, true
+}
+
+func (s *translationCacheStore) Set(key translate.CacheKey, text string) error {
+	return s.e.db.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(&model.TranslationCache{
+		Hash:   key.Hash(),
+		Engine: key.Engine,
+		Text:   text,
+	}).Error
+}
+
+func (s *translationCacheStore) Purge(engine string) error {
+	tx := s.e.db.Session(&gorm.Session{AllowGlobalUpdate: true})
+	if engine != "" {
+		tx = tx.Where("engine = ?", engine)
+	}
+	return tx.Delete(&model.TranslationCache{}).Error
+}
@@ -0,0 +1,41 @@
+package engine
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/metatube-community/metatube-sdk-go/common/fetch"
+	"github.com/metatube-community/metatube-sdk-go/errors"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+// FetchStream fetches streaming media from url, the Range and If-Range
+// headers are forwarded from header. Unlike Fetch, the response is not
+// bounded by the request timeout but ctx, e.g., of the client request,
+// and both 200 and 206 status codes are accepted. The Fetchers of
+// providers are not used, as they can neither forward the headers nor
+// stream beyond their request timeouts.
+func (e *Engine) FetchStream(ctx context.Context, url string, header http.Header, provider mt.Provider) (*http.Response, error) {
+	opts := []fetch.Option{
+		fetch.WithContext(ctx),
+		fetch.WithRaiseForStatus(false),
+		// Most providers check the referer of media resources.
+		fetch.WithReferer(provider.URL().String()),
+	}
+	for _, key := range []string{"Range", "If-Range"} {
+		if value := header.Get(key); value != "" {
+			opts = append(opts, fetch.WithHeader(key, value))
+		}
+	}
+	resp, err := e.streamFetcher.Get(url, opts...)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusPartialContent:
+		return resp, nil
+	default:
+		defer resp.Body.Close()
+		return nil, errors.FromCode(resp.StatusCode)
+	}
+}
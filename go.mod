@@ -12,7 +12,9 @@ require (
 	github.com/docker/go-units v0.5.0
 	github.com/elliotchance/orderedmap/v3 v3.1.1
 	github.com/esimov/pigo v1.4.7-0.20240801095032-7465ed14de47
+	github.com/gen2brain/avif v0.4.4
 	github.com/gen2brain/jpegli v0.4.1
+	github.com/gen2brain/webp v0.5.5
 	github.com/gin-contrib/cors v1.7.7
 	github.com/gin-gonic/gin v1.12.0
 	github.com/glebarez/sqlite v1.11.0
@@ -67,6 +69,7 @@ require (
 	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
 	github.com/docker/go-connections v0.5.0 // indirect
 	github.com/dustin/go-humanize v1.0.1 // indirect
+	github.com/ebitengine/purego v0.10.0 // indirect
 	github.com/gabriel-vasile/mimetype v1.4.13 // indirect
 	github.com/gin-contrib/sse v1.1.1 // indirect
 	github.com/glebarez/go-sqlite v1.22.0 // indirect
@@ -1,8 +1,12 @@
 package badge
 
 import (
+	"errors"
 	"fmt"
 	"image"
+	"image/draw"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jellydator/ttlcache/v3"
@@ -11,6 +15,95 @@ import (
 	"github.com/metatube-community/metatube-sdk-go/imageutil"
 )
 
+var ErrInvalidBadge = errors.New("invalid badge")
+
+// Default badge heights relative to the image height.
+const (
+	DefaultScale     = 0.2
+	DefaultTextScale = 0.08
+)
+
+// Position is the corner of the image to place a badge.
+type Position uint8
+
+const (
+	TopLeft Position = iota
+	TopRight
+	BottomLeft
+	BottomRight
+)
+
+var positionNames = map[string]Position{
+	"tl": TopLeft, "top-left": TopLeft,
+	"tr": TopRight, "top-right": TopRight,
+	"bl": BottomLeft, "bottom-left": BottomLeft,
+	"br": BottomRight, "bottom-right": BottomRight,
+}
+
+// ParsePosition parses a position name, e.g., tl or top-left.
+func ParsePosition(s string) (Position, bool) {
+	pos, ok := positionNames[strings.ToLower(s)]
+	return pos, ok
+}
+
+// Spec describes a badge to be rendered.
+type Spec struct {
+	// Source is a built-in badge name, a text badge
+	// prefixed with "text:", or the URL of a badge image.
+	Source   string
+	Position Position
+	// Scale is the badge height relative to the image height.
+	Scale float64
+}
+
+const textBadgePrefix = "text:"
+
+// Parse parses a badge spec in the form of source[|position][|scale],
+// e.g., "uncensored", "text:4K|br|0.1" or "https://.../badge.png|tr".
+func Parse(s string) (*Spec, error) {
+	fields := strings.Split(s, "|")
+	spec := &Spec{Position: TopLeft}
+	// options are parsed from the right, so that
+	// sources may contain the separator as well.
+	for len(fields) > 1 {
+		last := strings.TrimSpace(fields[len(fields)-1])
+		if pos, ok := ParsePosition(last); ok {
+			spec.Position = pos
+		} else if scale, err := strconv.ParseFloat(last, 64); err == nil {
+			if scale <= 0 || scale > 1 {
+				return nil, fmt.Errorf("invalid badge scale: %s", last)
+			}
+			spec.Scale = scale
+		} else {
+			break
+		}
+		fields = fields[:len(fields)-1]
+	}
+	spec.Source = strings.Join(fields, "|")
+	if spec.Source == "" {
+		return nil, ErrInvalidBadge
+	}
+	if spec.IsText() {
+		// texts the font cannot render, e.g., CJK
+		// texts with the embedded font, are rejected.
+		if err := validateText(getFont(), strings.TrimPrefix(spec.Source, textBadgePrefix)); err != nil {
+			return nil, err
+		}
+	}
+	if spec.Scale == 0 {
+		spec.Scale = DefaultScale
+		if spec.IsText() {
+			spec.Scale = DefaultTextScale
+		}
+	}
+	return spec, nil
+}
+
+// IsText reports whether the badge is rendered from text.
+func (s *Spec) IsText() bool {
+	return strings.HasPrefix(s.Source, textBadgePrefix)
+}
+
 var (
 	badgeCache = ttlcache.New[string, image.Image](
 		ttlcache.WithTTL[string, image.Image](30*time.Minute),
@@ -24,23 +117,78 @@ func init() {
 	go badgeCache.Start()
 }
 
-func Badge(src image.Image, badge string) (image.Image, error) {
-	var img image.Image
-	if item := badgeCache.Get(badge); item != nil {
-		img = item.Value()
-	} else {
-		resp, err := badgeFetcher.Fetch(badge)
-		if err != nil {
-			return nil, fmt.Errorf("fetch badge: %w", err)
+func fetchBadge(url string) (img image.Image, err error) {
+	if item := badgeCache.Get(url); item != nil {
+		return item.Value(), nil
+	}
+	resp, err := badgeFetcher.Fetch(url)
+	if err != nil {
+		return nil, fmt.Errorf("fetch badge: %w", err)
+	}
+	defer resp.Body.Close()
+	// decode badge image.
+	img, _, err = imageutil.Decode(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("decode badge: %w", err)
+	}
+	badgeCache.Set(url, img, ttlcache.DefaultTTL)
+	return img, nil
+}
+
+// load returns the badge image with the given height.
+func (s *Spec) load(height int) (image.Image, error) {
+	source := s.Source
+	if s.IsText() {
+		return renderText(strings.TrimPrefix(source, textBadgePrefix), height)
+	}
+	img, ok := getBuiltinBadge(source)
+	if !ok {
+		if !strings.HasPrefix(source, "http://") &&
+			!strings.HasPrefix(source, "https://") {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidBadge, source)
 		}
-		defer resp.Body.Close()
-		// decode badge image.
-		img, _, err = imageutil.Decode(resp.Body)
+		var err error
+		if img, err = fetchBadge(source); err != nil {
+			return nil, err
+		}
+	}
+	return imageutil.Resize(img, 0, height), nil
+}
+
+// Render draws the badges onto a copy of the source image in order.
+func Render(src image.Image, specs ...*Spec) (image.Image, error) {
+	if len(specs) == 0 {
+		return src, nil
+	}
+	b := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, b.Min, draw.Src)
+	for _, spec := range specs {
+		img, err := spec.load(max(int(float64(b.Dy())*spec.Scale), 1))
 		if err != nil {
-			return nil, fmt.Errorf("decode badge: %w", err)
+			return nil, err
 		}
-		badgeCache.Set(badge, img, ttlcache.DefaultTTL)
+		size := img.Bounds().Size()
+		var pt image.Point
+		switch spec.Position {
+		case TopRight:
+			pt = image.Pt(b.Dx()-size.X, 0)
+		case BottomLeft:
+			pt = image.Pt(0, b.Dy()-size.Y)
+		case BottomRight:
+			pt = image.Pt(b.Dx()-size.X, b.Dy()-size.Y)
+		}
+		draw.Draw(dst, image.Rectangle{Min: pt, Max: pt.Add(size)},
+			img, img.Bounds().Min, draw.Over)
+	}
+	return dst, nil
+}
+
+// Badge draws a single badge, see Parse for the spec format.
+func Badge(src image.Image, badge string) (image.Image, error) {
+	spec, err := Parse(badge)
+	if err != nil {
+		return nil, err
 	}
-	wmk := imageutil.Resize(img, 0, src.Bounds().Dy()/5 /* 0.2 */)
-	return imageutil.Watermark(src, wmk, image.Point{}), nil
+	return Render(src, spec)
 }
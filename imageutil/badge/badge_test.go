@@ -0,0 +1,68 @@
+package badge
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, unit := range []struct {
+		spec string
+		want *Spec
+		err  bool
+	}{
+		{"uncensored", &Spec{Source: "uncensored", Position: TopLeft, Scale: DefaultScale}, false},
+		{"zimu.png|tr", &Spec{Source: "zimu.png", Position: TopRight, Scale: DefaultScale}, false},
+		{"text:4K|br|0.1", &Spec{Source: "text:4K", Position: BottomRight, Scale: 0.1}, false},
+		{"text:A|B", &Spec{Source: "text:A|B", Position: TopLeft, Scale: DefaultTextScale}, false},
+		{"https://example.com/b.png|0.3|bottom-left", &Spec{Source: "https://example.com/b.png", Position: BottomLeft, Scale: 0.3}, false},
+		{"uncensored|2", nil, true},
+		{"text:", nil, true},
+		{"text:中字|tr", nil, true},
+		{"|tl", nil, true},
+	} {
+		spec, err := Parse(unit.spec)
+		if unit.err {
+			assert.Error(t, err, unit.spec)
+			continue
+		}
+		if assert.NoError(t, err, unit.spec) {
+			assert.Equal(t, unit.want, spec, unit.spec)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 400, 600))
+
+	specs := []*Spec{
+		{Source: Uncensored, Position: TopLeft, Scale: DefaultScale},
+		{Source: "text:4K", Position: BottomRight, Scale: 0.1},
+	}
+	img, err := Render(src, specs...)
+	require.NoError(t, err)
+	assert.Equal(t, src.Bounds(), img.Bounds())
+
+	// text badge background at the bottom-right corner.
+	r, g, b, _ := img.At(398, 598).RGBA()
+	assert.Equal(t, backgroundColor, color.RGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: 0xff})
+	// untouched center.
+	_, _, _, a := img.At(200, 300).RGBA()
+	assert.Zero(t, a)
+
+	_, err = Render(src, &Spec{Source: "unknown", Scale: DefaultScale})
+	assert.ErrorIs(t, err, ErrInvalidBadge)
+
+	// CJK glyphs are not covered by the embedded font.
+	_, err = Render(src, &Spec{Source: "text:中字", Scale: DefaultTextScale})
+	assert.ErrorIs(t, err, ErrInvalidBadge)
+}
+
+func TestFromNumber(t *testing.T) {
+	assert.Len(t, FromNumber("heyzo-1234"), 1)
+	assert.Empty(t, FromNumber("ABP-123"))
+}
@@ -0,0 +1,61 @@
+package badge
+
+import (
+	"bytes"
+	_ "embed"
+	"image"
+	"strings"
+
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+	"github.com/metatube-community/metatube-sdk-go/imageutil"
+)
+
+//go:embed zimu.png
+var zimu []byte
+
+//go:embed u.png
+var u []byte
+
+//go:embed uc.png
+var uc []byte
+
+// Built-in badge names.
+const (
+	Subtitle           = "subtitle"
+	Uncensored         = "uncensored"
+	UncensoredSubtitle = "uncensored-subtitle"
+)
+
+// builtinBadges maps names to embedded badges, the file
+// names are kept as aliases for backward compatibility.
+var builtinBadges = make(map[string]image.Image)
+
+func init() {
+	registerEmbeddedBadge(zimu, Subtitle, "zimu.png")
+	registerEmbeddedBadge(u, Uncensored, "u.png")
+	registerEmbeddedBadge(uc, UncensoredSubtitle, "uc.png")
+}
+
+func registerEmbeddedBadge(raw []byte, names ...string) {
+	badge, _, err := imageutil.Decode(bytes.NewReader(raw))
+	if err != nil || badge == nil {
+		return
+	}
+	for _, name := range names {
+		builtinBadges[name] = badge
+	}
+}
+
+func getBuiltinBadge(name string) (image.Image, bool) {
+	img, ok := builtinBadges[strings.ToLower(name)]
+	return img, ok
+}
+
+// FromNumber derives badges from the movie number.
+func FromNumber(s string) []*Spec {
+	var specs []*Spec
+	if number.IsUncensored(s) {
+		specs = append(specs, &Spec{Source: Uncensored, Position: TopLeft, Scale: DefaultScale})
+	}
+	return specs
+}
@@ -0,0 +1,101 @@
+package badge
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+var (
+	textColor       = color.White
+	backgroundColor = color.RGBA{R: 0xc8, G: 0x00, B: 0x0f, A: 0xff}
+)
+
+var (
+	fontMu    sync.RWMutex
+	badgeFont *opentype.Font
+)
+
+func init() {
+	badgeFont, _ = opentype.Parse(gobold.TTF)
+}
+
+// SetFont replaces the embedded Go Bold font for text badges. The
+// embedded font only covers Latin scripts, texts in other scripts,
+// e.g., "中字", are rejected unless a font with the glyphs is set.
+func SetFont(data []byte) error {
+	f, err := opentype.Parse(data)
+	if err != nil {
+		return err
+	}
+	fontMu.Lock()
+	defer fontMu.Unlock()
+	badgeFont = f
+	return nil
+}
+
+func getFont() *opentype.Font {
+	fontMu.RLock()
+	defer fontMu.RUnlock()
+	return badgeFont
+}
+
+// validateText returns an error if the text is empty, or the font
+// does not support any of its runes.
+func validateText(f *opentype.Font, text string) error {
+	if text == "" {
+		return fmt.Errorf("%w: empty text", ErrInvalidBadge)
+	}
+	var buf sfnt.Buffer
+	for _, r := range text {
+		if idx, err := f.GlyphIndex(&buf, r); err != nil || idx == 0 {
+			return fmt.Errorf("%w: font does not support %q", ErrInvalidBadge, r)
+		}
+	}
+	return nil
+}
+
+func renderText(text string, height int) (image.Image, error) {
+	f := getFont()
+	if err := validateText(f, text); err != nil {
+		return nil, err
+	}
+
+	// text takes 70% of the badge height.
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{
+		Size:    float64(height) * 0.7,
+		DPI:     72, /* 1pt = 1px */
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer face.Close()
+
+	var (
+		metrics = face.Metrics()
+		padding = height / 4
+		width   = font.MeasureString(face, text).Ceil() + padding*2
+	)
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(backgroundColor), image.Point{}, draw.Src)
+
+	// vertically center the text by its ascent and descent.
+	baseline := (fixed.I(height) + metrics.Ascent - metrics.Descent) / 2
+	d := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(textColor),
+		Face: face,
+		Dot:  fixed.Point26_6{X: fixed.I(padding), Y: baseline},
+	}
+	d.DrawString(text)
+	return img, nil
+}
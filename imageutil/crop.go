@@ -19,8 +19,16 @@ func CropImagePosition(img image.Image, ratio float64, pos float64) image.Image
 	if ratio < minRatio || ratio > maxRatio {
 		return img // no cropping
 	}
-	width := img.Bounds().Dx()
-	height := img.Bounds().Dy()
+	return CropImage(img, CropRectPosition(img.Bounds(), ratio, pos))
+}
+
+// CropRectPosition returns the rectangle that CropImagePosition crops.
+func CropRectPosition(bounds image.Rectangle, ratio float64, pos float64) image.Rectangle {
+	if ratio < minRatio || ratio > maxRatio {
+		return bounds // no cropping
+	}
+	width := bounds.Dx()
+	height := bounds.Dy()
 	var (
 		w, h = width, height //nolint:ineffassign
 		x, y = 0, 0          // default
@@ -30,7 +38,18 @@ func CropImagePosition(img image.Image, ratio float64, pos float64) image.Image
 	} else if h = int(float64(width) / ratio); h < height {
 		y = max(min(int(float64(height)*pos)-int(float64(h)/2), height-h), 0)
 	}
-	return CropImage(img,
-		image.Rect(0, 0, w, h).
-			Add(image.Pt(x, y)).Add(img.Bounds().Min))
+	return image.Rect(0, 0, w, h).
+		Add(image.Pt(x, y)).Add(bounds.Min).
+		Intersect(bounds)
+}
+
+// SquareRectAround returns the largest square within bounds, at most scale
+// times the size of r, which is centered on r as much as possible.
+func SquareRectAround(bounds, r image.Rectangle, scale float64) image.Rectangle {
+	side := int(float64(max(r.Dx(), r.Dy())) * scale)
+	side = max(min(side, bounds.Dx(), bounds.Dy()), 0)
+	center := r.Min.Add(r.Max).Div(2)
+	x := max(min(center.X-side/2, bounds.Max.X-side), bounds.Min.X)
+	y := max(min(center.Y-side/2, bounds.Max.Y-side), bounds.Min.Y)
+	return image.Rect(x, y, x+side, y+side)
 }
@@ -0,0 +1,26 @@
+package imageutil
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSquareRectAround(t *testing.T) {
+	bounds := image.Rect(0, 0, 800, 500)
+	for _, unit := range []struct {
+		r     image.Rectangle
+		scale float64
+		want  image.Rectangle
+	}{
+		{image.Rect(380, 230, 420, 270), 2, image.Rect(360, 210, 440, 290)},
+		// shifted inside the bounds.
+		{image.Rect(0, 0, 40, 40), 2, image.Rect(0, 0, 80, 80)},
+		{image.Rect(760, 460, 800, 500), 3, image.Rect(680, 380, 800, 500)},
+		// limited by the bounds.
+		{image.Rect(300, 200, 500, 300), 4, image.Rect(150, 0, 650, 500)},
+	} {
+		assert.Equal(t, unit.want, SquareRectAround(bounds, unit.r, unit.scale))
+	}
+}
@@ -1,11 +1,142 @@
 package imageutil
 
 import (
+	"errors"
 	"image"
 	"image/jpeg"
+	"image/png"
 	"io"
+	"strings"
+	"sync"
+
+	"github.com/gen2brain/avif"
+	"github.com/gen2brain/webp"
+)
+
+// Supported encoding formats.
+const (
+	JPEG = "jpeg"
+	PNG  = "png"
+	WebP = "webp"
+	AVIF = "avif"
 )
 
+var ErrUnsupportedFormat = errors.New("imageutil: unsupported format")
+
+// EncodeOptions are the common encoding parameters, encoders
+// should ignore any options they do not support.
+type EncodeOptions struct {
+	// Quality in the range [1,100].
+	Quality int
+	// Lossless compression, if supported.
+	Lossless bool
+}
+
+type EncodeFunc func(w io.Writer, m image.Image, o *EncodeOptions) error
+
+type encoder struct {
+	format   string
+	mimeType string
+	encode   EncodeFunc
+}
+
+var (
+	encodersMu sync.RWMutex
+	encoders   []encoder
+)
+
+func init() {
+	RegisterEncoder(JPEG, "image/jpeg", func(w io.Writer, m image.Image, o *EncodeOptions) error {
+		return EncodeToJPEG(w, m, o.Quality)
+	})
+	RegisterEncoder(PNG, "image/png", func(w io.Writer, m image.Image, _ *EncodeOptions) error {
+		return (&png.Encoder{CompressionLevel: png.BestSpeed}).Encode(w, m)
+	})
+	RegisterEncoder(WebP, "image/webp", func(w io.Writer, m image.Image, o *EncodeOptions) error {
+		return webp.Encode(w, m, webp.Options{
+			Quality:  o.Quality,
+			Lossless: o.Lossless,
+			Method:   webp.DefaultMethod,
+		})
+	})
+	RegisterEncoder(AVIF, "image/avif", func(w io.Writer, m image.Image, o *EncodeOptions) error {
+		quality := o.Quality
+		if o.Lossless {
+			quality = 100 // implies lossless.
+		}
+		return avif.Encode(w, m, avif.Options{
+			Quality:      quality,
+			QualityAlpha: quality,
+			Speed:        avif.DefaultSpeed, // fastest
+		})
+	})
+}
+
+// RegisterEncoder registers an image encoder by format name,
+// it overrides any existing encoder with the same format.
+func RegisterEncoder(format, mimeType string, fn EncodeFunc) {
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+	for i := range encoders {
+		if strings.EqualFold(encoders[i].format, format) {
+			encoders[i] = encoder{format, mimeType, fn}
+			return
+		}
+	}
+	encoders = append(encoders, encoder{format, mimeType, fn})
+}
+
+func lookupEncoder(match func(encoder) bool) (encoder, bool) {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+	for _, e := range encoders {
+		if match(e) {
+			return e, true
+		}
+	}
+	return encoder{}, false
+}
+
+// Formats returns all registered encoding formats.
+func Formats() []string {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+	formats := make([]string, 0, len(encoders))
+	for _, e := range encoders {
+		formats = append(formats, e.format)
+	}
+	return formats
+}
+
+// FormatMIMEType returns the MIME type of the given format.
+func FormatMIMEType(format string) (string, bool) {
+	e, ok := lookupEncoder(func(e encoder) bool { return strings.EqualFold(e.format, format) })
+	return e.mimeType, ok
+}
+
+// MIMETypeFormat returns the format of the given MIME type.
+func MIMETypeFormat(mimeType string) (string, bool) {
+	e, ok := lookupEncoder(func(e encoder) bool { return strings.EqualFold(e.mimeType, mimeType) })
+	return e.format, ok
+}
+
+// Encode writes the image m to w in the given format.
+func Encode(w io.Writer, m image.Image, format string, o *EncodeOptions) error {
+	e, ok := lookupEncoder(func(e encoder) bool { return strings.EqualFold(e.format, format) })
+	if !ok {
+		return ErrUnsupportedFormat
+	}
+	// never modify the options of callers.
+	var opts EncodeOptions
+	if o != nil {
+		opts = *o
+	}
+	if opts.Quality <= 0 || opts.Quality > 100 {
+		opts.Quality = jpeg.DefaultQuality
+	}
+	return e.encode(w, m, &opts)
+}
+
 func EncodeToJPEG(w io.Writer, m image.Image, quality int) error {
 	return jpeg.Encode(w, m, &jpeg.Options{Quality: quality})
 }
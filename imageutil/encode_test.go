@@ -0,0 +1,57 @@
+package imageutil
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 64, 48))
+	for x := 0; x < 64; x++ {
+		for y := 0; y < 48; y++ {
+			src.Set(x, y, color.NRGBA{R: uint8(x * 4), G: uint8(y * 5), B: 128, A: 255})
+		}
+	}
+
+	for _, unit := range []struct {
+		format   string
+		mimeType string
+		options  *EncodeOptions
+	}{
+		{JPEG, "image/jpeg", &EncodeOptions{Quality: 90}},
+		{PNG, "image/png", nil},
+		{WebP, "image/webp", &EncodeOptions{Quality: 80}},
+		{WebP, "image/webp", &EncodeOptions{Lossless: true}},
+		{AVIF, "image/avif", &EncodeOptions{Quality: 60}},
+	} {
+		t.Run(unit.format, func(t *testing.T) {
+			mimeType, ok := FormatMIMEType(unit.format)
+			require.True(t, ok)
+			assert.Equal(t, unit.mimeType, mimeType)
+
+			format, ok := MIMETypeFormat(unit.mimeType)
+			require.True(t, ok)
+			assert.Equal(t, unit.format, format)
+
+			buf := &bytes.Buffer{}
+			require.NoError(t, Encode(buf, src, unit.format, unit.options))
+
+			img, format, err := Decode(buf)
+			require.NoError(t, err)
+			assert.Equal(t, unit.format, format)
+			assert.Equal(t, src.Bounds().Size(), img.Bounds().Size())
+		})
+	}
+
+	// options of callers are left as is.
+	options := &EncodeOptions{Lossless: true}
+	require.NoError(t, Encode(&bytes.Buffer{}, src, WebP, options))
+	assert.Equal(t, &EncodeOptions{Lossless: true}, options)
+
+	assert.ErrorIs(t, Encode(&bytes.Buffer{}, src, "bmp", nil), ErrUnsupportedFormat)
+}
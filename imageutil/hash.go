@@ -2,6 +2,7 @@ package imageutil
 
 import (
 	"image"
+	"math/bits"
 
 	"github.com/corona10/goimagehash"
 )
@@ -12,6 +13,25 @@ const (
 	thPerceptionHash = 6
 )
 
+// SimilarHashDistance is the max distance between the perception
+// hashes of two images to be considered as the same picture.
+const SimilarHashDistance = thPerceptionHash - 1
+
+// PerceptionHash returns the 64-bit perception hash of the image,
+// which is compact enough to be stored and compared later.
+func PerceptionHash(img image.Image) (uint64, error) {
+	hash, err := goimagehash.PerceptionHash(img)
+	if err != nil {
+		return 0, err
+	}
+	return hash.GetHash(), nil
+}
+
+// HashDistance returns the hamming distance between two hashes.
+func HashDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
 func AverageHashDistance(imgA, imgB image.Image) (distance int) {
 	hashA, _ := goimagehash.AverageHash(imgA)
 	hashB, _ := goimagehash.AverageHash(imgB)
@@ -0,0 +1,33 @@
+package imageutil
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPerceptionHash(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 320, 240))
+	for y := 0; y < 240; y++ {
+		for x := 0; x < 320; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: uint8(x ^ y), A: 0xff})
+		}
+	}
+
+	hashA, err := PerceptionHash(img)
+	require.NoError(t, err)
+	// the same picture in a lower resolution.
+	hashB, err := PerceptionHash(imaging.Resize(img, 160, 120, imaging.Lanczos))
+	require.NoError(t, err)
+	// a different picture.
+	hashC, err := PerceptionHash(imaging.FlipH(img))
+	require.NoError(t, err)
+
+	assert.LessOrEqual(t, HashDistance(hashA, hashB), SimilarHashDistance)
+	assert.Greater(t, HashDistance(hashA, hashC), SimilarHashDistance)
+	assert.Equal(t, 0, HashDistance(hashA, hashA))
+}
@@ -2,12 +2,19 @@ package imageutil
 
 import (
 	"image"
+	"strings"
 
 	"github.com/disintegration/imaging"
 )
 
 // Resize provides a simple interface to resize image.
 func Resize(src image.Image, width, height int) image.Image {
+	return ResizeWithFilter(src, width, height, imaging.Lanczos)
+}
+
+// ResizeWithFilter resizes image with the given resampling filter,
+// a zero width or height preserves the aspect ratio.
+func ResizeWithFilter(src image.Image, width, height int, filter imaging.ResampleFilter) image.Image {
 	switch {
 	case width == 0 && height == 0:
 		return src /* not modified */
@@ -16,5 +23,56 @@ func Resize(src image.Image, width, height int) image.Image {
 	case height == 0:
 		height = int(float64(width) / float64(src.Bounds().Dx()) * float64(src.Bounds().Dy()))
 	}
-	return imaging.Resize(src, width, height, imaging.Lanczos)
+	return imaging.Resize(src, width, height, filter)
+}
+
+// Fit modes for ResizeFit.
+const (
+	// FitCover scales the image to cover the whole box,
+	// and crops the overflowed parts from the center.
+	FitCover = "cover"
+	// FitContain scales the image to fit inside the box
+	// while preserving the aspect ratio.
+	FitContain = "contain"
+	// FitFill stretches the image to the exact box size.
+	FitFill = "fill"
+)
+
+// ResizeFit resizes image into a width x height box with the given fit
+// mode, a zero width or height preserves the aspect ratio regardless of
+// the fit mode.
+func ResizeFit(src image.Image, width, height int, fit string, filter imaging.ResampleFilter) image.Image {
+	if width == 0 || height == 0 {
+		return ResizeWithFilter(src, width, height, filter)
+	}
+	switch strings.ToLower(fit) {
+	case FitContain:
+		return imaging.Fit(src, width, height, filter)
+	case FitFill:
+		return imaging.Resize(src, width, height, filter)
+	default: // FitCover
+		return imaging.Fill(src, width, height, imaging.Center, filter)
+	}
+}
+
+var resampleFilters = map[string]imaging.ResampleFilter{
+	"nearest":    imaging.NearestNeighbor,
+	"box":        imaging.Box,
+	"linear":     imaging.Linear,
+	"hermite":    imaging.Hermite,
+	"mitchell":   imaging.MitchellNetravali,
+	"catmullrom": imaging.CatmullRom,
+	"bspline":    imaging.BSpline,
+	"gaussian":   imaging.Gaussian,
+	"lanczos":    imaging.Lanczos,
+}
+
+// ParseResampleFilter returns the resampling filter by name,
+// Lanczos is returned if the name is empty.
+func ParseResampleFilter(name string) (imaging.ResampleFilter, bool) {
+	if name == "" {
+		return imaging.Lanczos, true
+	}
+	filter, ok := resampleFilters[strings.ToLower(name)]
+	return filter, ok
 }
@@ -0,0 +1,43 @@
+package imageutil
+
+import (
+	"image"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResizeFit(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 800, 538))
+
+	for _, unit := range []struct {
+		width, height int
+		fit           string
+		want          image.Point
+	}{
+		{0, 0, FitCover, image.Pt(800, 538)},
+		{400, 0, FitCover, image.Pt(400, 269)},
+		{0, 269, FitContain, image.Pt(400, 269)},
+		{300, 300, FitCover, image.Pt(300, 300)},
+		{300, 300, FitContain, image.Pt(300, 201)},
+		{300, 300, FitFill, image.Pt(300, 300)},
+		{300, 300, "", image.Pt(300, 300)},
+	} {
+		img := ResizeFit(src, unit.width, unit.height, unit.fit, imaging.Linear)
+		assert.Equal(t, unit.want, img.Bounds().Size(), "%dx%d %s", unit.width, unit.height, unit.fit)
+	}
+}
+
+func TestParseResampleFilter(t *testing.T) {
+	for name, ok := range map[string]bool{
+		"":           true,
+		"lanczos":    true,
+		"CatmullRom": true,
+		"nearest":    true,
+		"unknown":    false,
+	} {
+		_, found := ParseResampleFilter(name)
+		assert.Equal(t, ok, found, name)
+	}
+}
@@ -0,0 +1,28 @@
+package imageutil
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	"github.com/disintegration/imaging"
+)
+
+// Sprite composes images into a grid contact sheet with the given
+// columns, each image is scaled to cover a tile of tileWidth x tileHeight.
+func Sprite(images []image.Image, columns, tileWidth, tileHeight int) image.Image {
+	if len(images) == 0 || columns <= 0 || tileWidth <= 0 || tileHeight <= 0 {
+		return image.NewNRGBA(image.Rectangle{})
+	}
+	columns = min(columns, len(images))
+	rows := (len(images) + columns - 1) / columns
+
+	dst := image.NewNRGBA(image.Rect(0, 0, columns*tileWidth, rows*tileHeight))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.Black), image.Point{}, draw.Src)
+	for i, img := range images {
+		tile := imaging.Fill(img, tileWidth, tileHeight, imaging.Center, imaging.Lanczos)
+		pt := image.Pt(i%columns*tileWidth, i/columns*tileHeight)
+		draw.Draw(dst, tile.Bounds().Add(pt), tile, image.Point{}, draw.Src)
+	}
+	return dst
+}
@@ -0,0 +1,44 @@
+package imageutil
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSprite(t *testing.T) {
+	newImage := func(w, h int, c color.Color) image.Image {
+		img := image.NewNRGBA(image.Rect(0, 0, w, h))
+		for x := 0; x < w; x++ {
+			for y := 0; y < h; y++ {
+				img.Set(x, y, c)
+			}
+		}
+		return img
+	}
+
+	red := color.NRGBA{R: 255, A: 255}
+	blue := color.NRGBA{B: 255, A: 255}
+	images := []image.Image{
+		newImage(200, 100, red),
+		newImage(100, 200, blue),
+		newImage(300, 200, red),
+		newImage(160, 90, blue),
+		newImage(160, 90, red),
+	}
+
+	sprite := Sprite(images, 2, 40, 30)
+	assert.Equal(t, image.Pt(80, 90), sprite.Bounds().Size())
+	assert.Equal(t, red, sprite.At(20, 15))
+	assert.Equal(t, blue, sprite.At(60, 15))
+	assert.Equal(t, red, sprite.At(20, 75))
+	// empty trailing tile.
+	assert.Equal(t, color.NRGBA{A: 255}, sprite.At(60, 75))
+
+	sprite = Sprite(images[:2], 4, 40, 30)
+	assert.Equal(t, image.Pt(80, 30), sprite.Bounds().Size())
+
+	assert.True(t, Sprite(nil, 4, 40, 30).Bounds().Empty())
+}
@@ -0,0 +1,118 @@
+package imageutil
+
+import (
+	"image"
+	"image/color"
+)
+
+const (
+	// trimTolerance is the max per-channel difference (0-255)
+	// to be considered the same color, tolerating JPEG noise.
+	trimTolerance = 24
+	// trimLineRatio is the min ratio of border colored pixels in a line,
+	// so that uniform bands with small text or logos are trimmed too.
+	trimLineRatio = 0.9
+	// maxTrimRatio is the max ratio of each side to be trimmed,
+	// a larger band is more likely a part of the image itself.
+	maxTrimRatio = 0.25
+)
+
+// TrimBorders trims uniform-color margins, e.g., letterbox bars, from
+// all sides of the image. Watermarks over the content are not detected,
+// only those within such margins are trimmed along with them.
+func TrimBorders(img image.Image) image.Image {
+	rect := TrimBordersRect(img)
+	if rect == img.Bounds() {
+		return img
+	}
+	return CropImage(img, rect)
+}
+
+// TrimBordersRect returns the bounds of the image without borders.
+func TrimBordersRect(img image.Image) image.Rectangle {
+	b := img.Bounds()
+	if b.Dx() < 3 || b.Dy() < 3 {
+		return b
+	}
+	var (
+		maxX = int(float64(b.Dx()) * maxTrimRatio)
+		maxY = int(float64(b.Dy()) * maxTrimRatio)
+	)
+	top := trimEdge(maxY, b.Dx(), func(n, i int) color.Color { return img.At(b.Min.X+i, b.Min.Y+n) })
+	bottom := trimEdge(maxY, b.Dx(), func(n, i int) color.Color { return img.At(b.Min.X+i, b.Max.Y-1-n) })
+	left := trimEdge(maxX, b.Dy(), func(n, i int) color.Color { return img.At(b.Min.X+n, b.Min.Y+i) })
+	right := trimEdge(maxX, b.Dy(), func(n, i int) color.Color { return img.At(b.Max.X-1-n, b.Min.Y+i) })
+	return image.Rect(
+		b.Min.X+left, b.Min.Y+top,
+		b.Max.X-right, b.Max.Y-bottom,
+	)
+}
+
+// trimEdge returns the number of border lines from an edge, or zero if
+// the border exceeds limit lines, at returns the i-th pixel of n-th line.
+func trimEdge(limit, size int, at func(n, i int) color.Color) int {
+	ref, ok := dominantColor(size, func(i int) color.Color { return at(0, i) })
+	if !ok {
+		return 0
+	}
+	for n := 0; n < limit; n++ {
+		var matched int
+		for i := 0; i < size; i++ {
+			if similarColor(at(n, i), ref) {
+				matched++
+			}
+		}
+		if float64(matched) < float64(size)*trimLineRatio {
+			return n
+		}
+	}
+	return 0 // too large to be a border.
+}
+
+// dominantColor returns the average color of the line if most of its
+// pixels share the same color, which is the reference border color.
+func dominantColor(size int, at func(int) color.Color) (color.Color, bool) {
+	type bucket struct {
+		n       int
+		r, g, b int
+	}
+	buckets := make(map[uint32]*bucket)
+	var best *bucket
+	for i := 0; i < size; i++ {
+		r, g, b, _ := at(i).RGBA()
+		r, g, b = r>>8, g>>8, b>>8
+		key := r>>5<<6 | g>>5<<3 | b>>5 // 3 bits per channel.
+		bk, ok := buckets[key]
+		if !ok {
+			bk = &bucket{}
+			buckets[key] = bk
+		}
+		bk.n++
+		bk.r += int(r)
+		bk.g += int(g)
+		bk.b += int(b)
+		if best == nil || bk.n > best.n {
+			best = bk
+		}
+	}
+	if best == nil || float64(best.n) < float64(size)*trimLineRatio {
+		return nil, false
+	}
+	return color.RGBA{
+		R: uint8(best.r / best.n),
+		G: uint8(best.g / best.n),
+		B: uint8(best.b / best.n),
+		A: 0xff,
+	}, true
+}
+
+func similarColor(c1, c2 color.Color) bool {
+	r1, g1, b1, _ := c1.RGBA()
+	r2, g2, b2, _ := c2.RGBA()
+	diff := func(a, b uint32) int {
+		return max(int(a>>8)-int(b>>8), int(b>>8)-int(a>>8))
+	}
+	return diff(r1, r2) <= trimTolerance &&
+		diff(g1, g2) <= trimTolerance &&
+		diff(b1, b2) <= trimTolerance
+}
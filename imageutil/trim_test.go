@@ -0,0 +1,49 @@
+package imageutil
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrimBordersRect(t *testing.T) {
+	// gradient content which never looks like a border.
+	content := func(r image.Rectangle, dst *image.RGBA) {
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			for x := r.Min.X; x < r.Max.X; x++ {
+				dst.Set(x, y, color.RGBA{R: uint8(x * 7), G: uint8(y * 5), B: uint8(x + y), A: 0xff})
+			}
+		}
+	}
+	fill := func(w, h int, bg color.Color, inner image.Rectangle) *image.RGBA {
+		img := image.NewRGBA(image.Rect(0, 0, w, h))
+		draw.Draw(img, img.Bounds(), image.NewUniform(bg), image.Point{}, draw.Src)
+		content(inner, img)
+		return img
+	}
+
+	for _, unit := range []struct {
+		name string
+		img  image.Image
+		want image.Rectangle
+	}{
+		{"letterbox", fill(200, 100, color.Black, image.Rect(0, 10, 200, 90)), image.Rect(0, 10, 200, 90)},
+		{"pillarbox", fill(200, 100, color.White, image.Rect(20, 0, 180, 100)), image.Rect(20, 0, 180, 100)},
+		{"no-border", fill(200, 100, color.Black, image.Rect(0, 0, 200, 100)), image.Rect(0, 0, 200, 100)},
+		// too large to be a border, keep it untouched.
+		{"large-band", fill(200, 100, color.Black, image.Rect(0, 40, 200, 100)), image.Rect(0, 0, 200, 100)},
+		{"logo-band", func() image.Image {
+			img := fill(200, 100, color.White, image.Rect(0, 0, 200, 88))
+			// small logo text within the bottom band.
+			content(image.Rect(90, 92, 100, 96), img)
+			return img
+		}(), image.Rect(0, 0, 200, 88)},
+	} {
+		t.Run(unit.name, func(t *testing.T) {
+			assert.Equal(t, unit.want, TrimBordersRect(unit.img))
+		})
+	}
+}
@@ -19,6 +19,15 @@ var metaTubeEnvs *maps.CaseInsensitiveMap[string]
 var (
 	ActorProviderConfigs *maps.CaseInsensitiveMap[*Config]
 	MovieProviderConfigs *maps.CaseInsensitiveMap[*Config]
+	// TranslateConfig configures server-side translation,
+	// e.g., MT_TRANSLATE__ENGINE=deepl.
+	TranslateConfig *Config
+	// TranslateChainConfigs maps chain names to ordered engines,
+	// e.g., MT_TRANSLATE_CHAIN__DEFAULT=deepl,openai,googlefree.
+	TranslateChainConfigs *Config
+	// TranslateProfileConfigs configures named translator profiles,
+	// e.g., MT_TRANSLATE_PROFILE_DEFAULT__ENGINE=deepl.
+	TranslateProfileConfigs *maps.CaseInsensitiveMap[*Config]
 )
 
 func init() {
@@ -29,6 +38,10 @@ func InitAllEnvConfigs() {
 	metaTubeEnvs = initMetaTubeEnvs()
 	ActorProviderConfigs = initProviderConfigs("actor")
 	MovieProviderConfigs = initProviderConfigs("movie")
+	TranslateConfig = initTranslateConfig()
+	TranslateChainConfigs = initTranslateChainConfigs()
+	TranslateProfileConfigs = parseProviderEnvsWithPrefix(
+		metaTubeEnvPrefix + "TRANSLATE_PROFILE_")
 }
 
 func initMetaTubeEnvs() *maps.CaseInsensitiveMap[string] {
@@ -57,6 +70,24 @@ func initProviderConfigs(providerType string) *maps.CaseInsensitiveMap[*Config]
 	return mergeProviderConfigs(typed, common)
 }
 
+func initTranslateConfig() *Config {
+	return parseConfigEnvsWithPrefix(metaTubeEnvPrefix + "TRANSLATE" + metaTubeConfigSep)
+}
+
+func initTranslateChainConfigs() *Config {
+	return parseConfigEnvsWithPrefix(metaTubeEnvPrefix + "TRANSLATE_CHAIN" + metaTubeConfigSep)
+}
+
+func parseConfigEnvsWithPrefix(prefix string) *Config {
+	config := NewConfig()
+	for key, value := range metaTubeEnvs.Iterator() {
+		if configKey, found := strings.CutPrefix(key, prefix); found && configKey != "" {
+			config.Set(configKey, value)
+		}
+	}
+	return config
+}
+
 func parseProviderEnvsWithPrefix(prefix string) *maps.CaseInsensitiveMap[*Config] {
 	result := maps.NewCaseInsensitiveMap[*Config]()
 	for key, value := range metaTubeEnvs.Iterator() {
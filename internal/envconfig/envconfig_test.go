@@ -25,6 +25,11 @@ func TestMetaTubeEnvConfigs(t *testing.T) {
 		{"MT_PROVIDER_UVW__PRIORITY", "5"},           // -> actor/movie
 		{"MT_MOVIE_PROVIDER_UVW__PRIORITY", "0"},     // override movie
 		{"MT_MOVIE_PROVIDER_JJJ_KKK__PRIORITY", "0"}, // hyphen in name
+		{"MT_TRANSLATE__ENGINE", "deepl"},
+		{"MT_TRANSLATE__DEEPL_API_KEY", "key"},
+		{"MT_TRANSLATE_CHAIN__DEFAULT", "deepl,openai,googlefree"},
+		{"MT_TRANSLATE_PROFILE_WORK_DEEPL__ENGINE", "deepl"},
+		{"MT_TRANSLATE_PROFILE_WORK_DEEPL__DEEPL_API_KEY", "key2"},
 		{"irrelevant_key", "ignore_me"},
 		{"mt_malformed_key", "ignore_me"},
 	} {
@@ -72,4 +77,26 @@ func TestMetaTubeEnvConfigs(t *testing.T) {
 	if assert.NoError(t, err) {
 		assert.Equal(t, 900*time.Second, timeout)
 	}
+
+	engine, err := TranslateConfig.GetString("engine")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "deepl", engine)
+	}
+	apiKey, err := TranslateConfig.GetString("deepl_api_key")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "key", apiKey)
+	}
+	assert.False(t, TranslateConfig.Has("chain__default"))
+
+	chain, err := TranslateChainConfigs.GetString("default")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "deepl,openai,googlefree", chain)
+	}
+
+	for _, name := range []string{"work_deepl", "work-deepl"} {
+		apiKey, err = TranslateProfileConfigs.GetOrDefault(name).GetString("deepl_api_key")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "key2", apiKey)
+		}
+	}
 }
@@ -0,0 +1,19 @@
+package model
+
+const GlossaryTermTableName = "glossary_terms"
+
+// GlossaryTerm is a term of the translation glossary.
+type GlossaryTerm struct {
+	Term string `gorm:"primaryKey"`
+	// Source and target languages, empty for any language.
+	SourceLang  string `gorm:"primaryKey"`
+	TargetLang  string `gorm:"primaryKey"`
+	Translation string
+	Protected   bool
+	Kind        string `gorm:"index"`
+	TimeTracker `json:"-"`
+}
+
+func (*GlossaryTerm) TableName() string {
+	return GlossaryTermTableName
+}
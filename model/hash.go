@@ -0,0 +1,25 @@
+package model
+
+const MovieCoverHashTableName = "movie_cover_hash"
+
+// MovieCoverHash is the perception hash of the cover of a movie,
+// it's keyed the same as the movie metadata.
+type MovieCoverHash struct {
+	ID       string `gorm:"primaryKey"`
+	Provider string `gorm:"primaryKey"`
+	// URL of the hashed cover, to tell whether the hash is stale.
+	URL string
+	// Hash stores the bits of the uint64 hash, since
+	// some databases do not support unsigned integers.
+	Hash        int64 `gorm:"index"`
+	TimeTracker `json:"-"`
+}
+
+func (*MovieCoverHash) TableName() string {
+	return MovieCoverHashTableName
+}
+
+// Uint64 returns the hash as a uint64.
+func (h *MovieCoverHash) Uint64() uint64 {
+	return uint64(h.Hash)
+}
@@ -0,0 +1,22 @@
+package model
+
+const MovieCropPositionTableName = "movie_crop_position"
+
+// MovieCropPosition is the auto-detected crop position of a movie image,
+// it's reused unless the source image URL changes.
+type MovieCropPosition struct {
+	ID       string  `gorm:"primaryKey"`
+	Provider string  `gorm:"primaryKey"`
+	Ratio    float64 `gorm:"primaryKey"`
+	Trimmed  bool    `gorm:"primaryKey"`
+	// URL of the source image.
+	URL string
+	// Method used to find the position, e.g., face or saliency.
+	Method      string
+	Position    float64
+	TimeTracker `json:"-"`
+}
+
+func (*MovieCropPosition) TableName() string {
+	return MovieCropPositionTableName
+}
@@ -0,0 +1,16 @@
+package model
+
+const TranslationCacheTableName = "translation_cache"
+
+// TranslationCache is a cached translation, keyed by the hash of the
+// engine, its variant, the languages and the source text.
+type TranslationCache struct {
+	Hash        string `gorm:"primaryKey"`
+	Engine      string `gorm:"index"`
+	Text        string
+	TimeTracker `json:"-"`
+}
+
+func (*TranslationCache) TableName() string {
+	return TranslationCacheTableName
+}
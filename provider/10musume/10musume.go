@@ -8,8 +8,9 @@ import (
 )
 
 var (
-	_ provider.MovieProvider = (*TenMusume)(nil)
-	_ provider.MovieReviewer = (*TenMusume)(nil)
+	_ provider.MovieProvider     = (*TenMusume)(nil)
+	_ provider.MovieReviewer     = (*TenMusume)(nil)
+	_ provider.MovieNumberRouter = (*TenMusume)(nil)
 )
 
 const (
@@ -59,6 +60,14 @@ func (mse *TenMusume) NormalizeMovieID(id string) string {
 	return ""
 }
 
+var numberPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^\d{6}[-_]\d{2}$`),
+}
+
+func (mse *TenMusume) MovieNumberPatterns() []*regexp.Regexp {
+	return numberPatterns
+}
+
 func init() {
 	provider.Register(Name, New)
 }
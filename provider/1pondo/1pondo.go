@@ -8,9 +8,10 @@ import (
 )
 
 var (
-	_ provider.MovieProvider = (*OnePondo)(nil)
-	_ provider.MovieReviewer = (*OnePondo)(nil)
-	_ provider.Fetcher       = (*OnePondo)(nil)
+	_ provider.MovieProvider     = (*OnePondo)(nil)
+	_ provider.MovieReviewer     = (*OnePondo)(nil)
+	_ provider.Fetcher           = (*OnePondo)(nil)
+	_ provider.MovieNumberRouter = (*OnePondo)(nil)
 )
 
 const (
@@ -60,6 +61,14 @@ func (opd *OnePondo) NormalizeMovieID(id string) string {
 	return ""
 }
 
+var numberPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^\d{6}[-_]\d{3}$`),
+}
+
+func (opd *OnePondo) MovieNumberPatterns() []*regexp.Regexp {
+	return numberPatterns
+}
+
 func init() {
 	provider.Register(Name, New)
 }
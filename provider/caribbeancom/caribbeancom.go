@@ -8,8 +8,9 @@ import (
 )
 
 var (
-	_ provider.MovieProvider = (*Caribbeancom)(nil)
-	_ provider.MovieReviewer = (*Caribbeancom)(nil)
+	_ provider.MovieProvider     = (*Caribbeancom)(nil)
+	_ provider.MovieReviewer     = (*Caribbeancom)(nil)
+	_ provider.MovieNumberRouter = (*Caribbeancom)(nil)
 )
 
 const (
@@ -45,6 +46,14 @@ func (carib *Caribbeancom) NormalizeMovieID(id string) string {
 	return ""
 }
 
+var numberPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^\d{6}[-_]\d{3}$`),
+}
+
+func (carib *Caribbeancom) MovieNumberPatterns() []*regexp.Regexp {
+	return numberPatterns
+}
+
 func init() {
 	provider.Register(Name, New)
 }
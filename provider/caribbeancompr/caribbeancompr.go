@@ -8,8 +8,9 @@ import (
 )
 
 var (
-	_ provider.MovieProvider = (*CaribbeancomPremium)(nil)
-	_ provider.MovieReviewer = (*CaribbeancomPremium)(nil)
+	_ provider.MovieProvider     = (*CaribbeancomPremium)(nil)
+	_ provider.MovieReviewer     = (*CaribbeancomPremium)(nil)
+	_ provider.MovieNumberRouter = (*CaribbeancomPremium)(nil)
 )
 
 const (
@@ -45,6 +46,14 @@ func (carib *CaribbeancomPremium) NormalizeMovieID(id string) string {
 	return ""
 }
 
+var numberPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^\d{6}[-_]\d{3}$`),
+}
+
+func (carib *CaribbeancomPremium) MovieNumberPatterns() []*regexp.Regexp {
+	return numberPatterns
+}
+
 func init() {
 	provider.Register(Name, New)
 }
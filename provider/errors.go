@@ -12,6 +12,7 @@ var (
 	ErrInvalidKeyword     = errors.New(http.StatusBadRequest, "invalid keyword")
 	ErrInfoNotFound       = errors.New(http.StatusNotFound, "info not found")
 	ErrImageNotFound      = errors.New(http.StatusNotFound, "image not found")
+	ErrVideoNotFound      = errors.New(http.StatusNotFound, "video not found")
 	ErrProviderNotFound   = errors.New(http.StatusNotFound, "provider not found")
 	ErrIncompleteMetadata = errors.New(http.StatusInternalServerError, "incomplete metadata")
 )
@@ -18,7 +18,10 @@ import (
 	"github.com/metatube-community/metatube-sdk-go/provider/internal/scraper"
 )
 
-var _ provider.MovieProvider = (*FC2)(nil)
+var (
+	_ provider.MovieProvider     = (*FC2)(nil)
+	_ provider.MovieNumberRouter = (*FC2)(nil)
+)
 
 const (
 	Name     = "FC2"
@@ -43,6 +46,10 @@ func (fc2 *FC2) NormalizeMovieID(id string) string {
 	return fc2util.ParseNumber(id)
 }
 
+func (fc2 *FC2) MovieNumberPatterns() []*regexp.Regexp {
+	return []*regexp.Regexp{fc2util.NumberPattern}
+}
+
 func (fc2 *FC2) GetMovieInfoByID(id string) (info *model.MovieInfo, err error) {
 	return fc2.GetMovieInfoByURL(fmt.Sprintf(movieURL, id))
 }
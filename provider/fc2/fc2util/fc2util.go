@@ -7,6 +7,9 @@ import (
 
 var fc2pattern = regexp.MustCompile(`^(?i)(?:FC2(?:[-_\s]?PPV)?[-_\s]?)?(\d+)$`)
 
+// NumberPattern matches FC2 numbers, the FC2 prefix is required.
+var NumberPattern = regexp.MustCompile(`^(?i)FC2(?:[-_\s]?PPV)?[-_\s]?\d+$`)
+
 func ParseNumber(id string) string {
 	ss := fc2pattern.FindStringSubmatch(id)
 	if len(ss) != 2 {
@@ -31,3 +31,18 @@ func TestParseNumber(t *testing.T) {
 		assert.Equal(t, unit.want, ParseNumber(unit.orig), unit.orig)
 	}
 }
+
+func TestNumberPattern(t *testing.T) {
+	for _, unit := range []struct {
+		orig string
+		want bool
+	}{
+		{"FC2-738573", true},
+		{"FC2-PPV-738573", true},
+		{"fc2ppv_738573", true},
+		{"738573", false},
+		{"FC3-PPV-12345", false},
+	} {
+		assert.Equal(t, unit.want, NumberPattern.MatchString(unit.orig), unit.orig)
+	}
+}
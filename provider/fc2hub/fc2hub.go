@@ -20,8 +20,9 @@ import (
 )
 
 var (
-	_ provider.MovieProvider = (*FC2HUB)(nil)
-	_ provider.MovieSearcher = (*FC2HUB)(nil)
+	_ provider.MovieProvider     = (*FC2HUB)(nil)
+	_ provider.MovieSearcher     = (*FC2HUB)(nil)
+	_ provider.MovieNumberRouter = (*FC2HUB)(nil)
 )
 
 const (
@@ -217,6 +218,10 @@ func (fc2hub *FC2HUB) NormalizeMovieKeyword(keyword string) string {
 	return fc2util.ParseNumber(keyword)
 }
 
+func (fc2hub *FC2HUB) MovieNumberPatterns() []*regexp.Regexp {
+	return []*regexp.Regexp{fc2util.NumberPattern}
+}
+
 func (fc2hub *FC2HUB) SearchMovie(keyword string) (results []*model.MovieSearchResult, err error) {
 	c := fc2hub.ClonedCollector()
 	c.ParseHTTPErrorResponse = true
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"regexp"
 	"strings"
 
 	"github.com/antchfx/htmlquery"
@@ -18,7 +19,10 @@ import (
 	"github.com/metatube-community/metatube-sdk-go/provider/internal/scraper"
 )
 
-var _ provider.MovieProvider = (*FC2PPVDB)(nil)
+var (
+	_ provider.MovieProvider     = (*FC2PPVDB)(nil)
+	_ provider.MovieNumberRouter = (*FC2PPVDB)(nil)
+)
 
 const (
 	Name     = "FC2PPVDB"
@@ -42,6 +46,10 @@ func (fc2ppvdb *FC2PPVDB) NormalizeMovieID(id string) string {
 	return fc2util.ParseNumber(id)
 }
 
+func (fc2ppvdb *FC2PPVDB) MovieNumberPatterns() []*regexp.Regexp {
+	return []*regexp.Regexp{fc2util.NumberPattern}
+}
+
 func (fc2ppvdb *FC2PPVDB) GetMovieInfoByID(id string) (info *model.MovieInfo, err error) {
 	return fc2ppvdb.GetMovieInfoByURL(fmt.Sprintf(movieURL, id))
 }
@@ -17,7 +17,10 @@ import (
 	"github.com/metatube-community/metatube-sdk-go/provider/internal/scraper"
 )
 
-var _ provider.MovieProvider = (*Gcolle)(nil)
+var (
+	_ provider.MovieProvider     = (*Gcolle)(nil)
+	_ provider.MovieNumberRouter = (*Gcolle)(nil)
+)
 
 const (
 	Name     = "Gcolle"
@@ -49,6 +52,14 @@ func (gcl *Gcolle) NormalizeMovieID(id string) string {
 	return ""
 }
 
+var numberPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^(?i)gcolle[-_]\d+$`),
+}
+
+func (gcl *Gcolle) MovieNumberPatterns() []*regexp.Regexp {
+	return numberPatterns
+}
+
 func (gcl *Gcolle) GetMovieInfoByID(id string) (info *model.MovieInfo, err error) {
 	return gcl.GetMovieInfoByURL(fmt.Sprintf(movieURL, id))
 }
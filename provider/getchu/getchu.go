@@ -18,7 +18,10 @@ import (
 	"github.com/metatube-community/metatube-sdk-go/provider/internal/scraper"
 )
 
-var _ provider.MovieProvider = (*Getchu)(nil)
+var (
+	_ provider.MovieProvider     = (*Getchu)(nil)
+	_ provider.MovieNumberRouter = (*Getchu)(nil)
+)
 
 const (
 	Name     = "Getchu"
@@ -45,6 +48,14 @@ func (gcu *Getchu) NormalizeMovieID(id string) string {
 	return ""
 }
 
+var numberPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^(?i)getchu[-_]\d+$`),
+}
+
+func (gcu *Getchu) MovieNumberPatterns() []*regexp.Regexp {
+	return numberPatterns
+}
+
 func (gcu *Getchu) GetMovieInfoByID(id string) (info *model.MovieInfo, err error) {
 	return gcu.GetMovieInfoByURL(fmt.Sprintf(movieURL, id))
 }
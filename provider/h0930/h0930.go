@@ -8,7 +8,10 @@ import (
 	"github.com/metatube-community/metatube-sdk-go/provider/h0930/core"
 )
 
-var _ provider.MovieProvider = (*H0930)(nil)
+var (
+	_ provider.MovieProvider     = (*H0930)(nil)
+	_ provider.MovieNumberRouter = (*H0930)(nil)
+)
 
 const (
 	Name     = "H0930"
@@ -43,6 +46,14 @@ func (h *H0930) NormalizeMovieID(id string) string {
 	return ""
 }
 
+var numberPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^(?i)h0930[-_][a-z\d]+$`),
+}
+
+func (h *H0930) MovieNumberPatterns() []*regexp.Regexp {
+	return numberPatterns
+}
+
 func init() {
 	provider.Register(Name, New)
 }
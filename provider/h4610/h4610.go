@@ -8,7 +8,10 @@ import (
 	"github.com/metatube-community/metatube-sdk-go/provider/h0930/core"
 )
 
-var _ provider.MovieProvider = (*H4610)(nil)
+var (
+	_ provider.MovieProvider     = (*H4610)(nil)
+	_ provider.MovieNumberRouter = (*H4610)(nil)
+)
 
 const (
 	Name     = "H4610"
@@ -43,6 +46,14 @@ func (h *H4610) NormalizeMovieID(id string) string {
 	return ""
 }
 
+var numberPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^(?i)h4610[-_][a-z\d]+$`),
+}
+
+func (h *H4610) MovieNumberPatterns() []*regexp.Regexp {
+	return numberPatterns
+}
+
 func init() {
 	provider.Register(Name, New)
 }
@@ -21,7 +21,10 @@ import (
 	"github.com/metatube-community/metatube-sdk-go/provider/internal/scraper"
 )
 
-var _ provider.MovieProvider = (*HeyDouga)(nil)
+var (
+	_ provider.MovieProvider     = (*HeyDouga)(nil)
+	_ provider.MovieNumberRouter = (*HeyDouga)(nil)
+)
 
 const (
 	Name     = "HeyDouga"
@@ -58,6 +61,14 @@ func (hey *HeyDouga) NormalizeMovieID(id string) string {
 	return ""
 }
 
+var numberPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^(?i)heydouga[-_]\d{4}-[a-z\d]+$`),
+}
+
+func (hey *HeyDouga) MovieNumberPatterns() []*regexp.Regexp {
+	return numberPatterns
+}
+
 func (hey *HeyDouga) GetMovieInfoByID(id string) (info *model.MovieInfo, err error) {
 	if ss := strings.SplitN(id, "-", 2); len(ss) == 2 {
 		return hey.GetMovieInfoByURL(fmt.Sprintf(movieURL, ss[0], ss[1]))
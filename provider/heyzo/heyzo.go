@@ -22,8 +22,9 @@ import (
 )
 
 var (
-	_ provider.MovieProvider = (*Heyzo)(nil)
-	_ provider.MovieReviewer = (*Heyzo)(nil)
+	_ provider.MovieProvider     = (*Heyzo)(nil)
+	_ provider.MovieReviewer     = (*Heyzo)(nil)
+	_ provider.MovieNumberRouter = (*Heyzo)(nil)
 )
 
 const (
@@ -131,6 +132,14 @@ func (hzo *Heyzo) NormalizeMovieID(id string) string {
 	return ""
 }
 
+var numberPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^(?i)heyzo[-_]\d+$`),
+}
+
+func (hzo *Heyzo) MovieNumberPatterns() []*regexp.Regexp {
+	return numberPatterns
+}
+
 func (hzo *Heyzo) GetMovieInfoByID(id string) (info *model.MovieInfo, err error) {
 	return hzo.GetMovieInfoByURL(fmt.Sprintf(movieURL, id))
 }
@@ -21,7 +21,10 @@ import (
 	"github.com/metatube-community/metatube-sdk-go/provider/internal/scraper"
 )
 
-var _ provider.MovieProvider = (*KIN8)(nil)
+var (
+	_ provider.MovieProvider     = (*KIN8)(nil)
+	_ provider.MovieNumberRouter = (*KIN8)(nil)
+)
 
 const (
 	Name     = "KIN8"
@@ -49,6 +52,14 @@ func (k8 *KIN8) NormalizeMovieID(id string) string {
 	return ""
 }
 
+var numberPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^(?i)kin8[-_]\d+$`),
+}
+
+func (k8 *KIN8) MovieNumberPatterns() []*regexp.Regexp {
+	return numberPatterns
+}
+
 func (k8 *KIN8) GetMovieInfoByID(id string) (info *model.MovieInfo, err error) {
 	return k8.GetMovieInfoByURL(fmt.Sprintf(movieURL, id))
 }
@@ -9,8 +9,9 @@ import (
 )
 
 var (
-	_ provider.MovieProvider = (*MuraMura)(nil)
-	_ provider.MovieReviewer = (*MuraMura)(nil)
+	_ provider.MovieProvider     = (*MuraMura)(nil)
+	_ provider.MovieReviewer     = (*MuraMura)(nil)
+	_ provider.MovieNumberRouter = (*MuraMura)(nil)
 )
 
 const (
@@ -54,6 +55,14 @@ func (ppm *MuraMura) NormalizeMovieID(id string) string {
 	return ""
 }
 
+var numberPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^\d{6}[-_]\d{3}$`),
+}
+
+func (ppm *MuraMura) MovieNumberPatterns() []*regexp.Regexp {
+	return numberPatterns
+}
+
 func init() {
 	provider.Register(Name, New)
 }
@@ -15,7 +15,10 @@ import (
 	"github.com/metatube-community/metatube-sdk-go/provider/internal/scraper"
 )
 
-var _ provider.MovieProvider = (*MyWife)(nil)
+var (
+	_ provider.MovieProvider     = (*MyWife)(nil)
+	_ provider.MovieNumberRouter = (*MyWife)(nil)
+)
 
 const (
 	Name     = "MYWIFE"
@@ -42,6 +45,14 @@ func (mw *MyWife) NormalizeMovieID(id string) string {
 	return ""
 }
 
+var numberPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^(?i)mywife[-_]\d+$`),
+}
+
+func (mw *MyWife) MovieNumberPatterns() []*regexp.Regexp {
+	return numberPatterns
+}
+
 func (mw *MyWife) GetMovieInfoByID(id string) (info *model.MovieInfo, err error) {
 	return mw.GetMovieInfoByURL(fmt.Sprintf(movieURL, id))
 }
@@ -8,8 +8,9 @@ import (
 )
 
 var (
-	_ provider.MovieProvider = (*Pacopacomama)(nil)
-	_ provider.MovieReviewer = (*Pacopacomama)(nil)
+	_ provider.MovieProvider     = (*Pacopacomama)(nil)
+	_ provider.MovieReviewer     = (*Pacopacomama)(nil)
+	_ provider.MovieNumberRouter = (*Pacopacomama)(nil)
 )
 
 const (
@@ -59,6 +60,14 @@ func (ppm *Pacopacomama) NormalizeMovieID(id string) string {
 	return ""
 }
 
+var numberPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^\d{6}[-_]\d{3}$`),
+}
+
+func (ppm *Pacopacomama) MovieNumberPatterns() []*regexp.Regexp {
+	return numberPatterns
+}
+
 func init() {
 	provider.Register(Name, New)
 }
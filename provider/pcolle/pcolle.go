@@ -18,7 +18,10 @@ import (
 	"github.com/metatube-community/metatube-sdk-go/provider/internal/scraper"
 )
 
-var _ provider.MovieProvider = (*Pcolle)(nil)
+var (
+	_ provider.MovieProvider     = (*Pcolle)(nil)
+	_ provider.MovieNumberRouter = (*Pcolle)(nil)
+)
 
 const (
 	Name     = "Pcolle"
@@ -51,6 +54,14 @@ func (pcl *Pcolle) NormalizeMovieID(id string) string {
 	return ""
 }
 
+var numberPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^(?i)pcolle[-_][a-z\d]{9,}$`),
+}
+
+func (pcl *Pcolle) MovieNumberPatterns() []*regexp.Regexp {
+	return numberPatterns
+}
+
 func (pcl *Pcolle) GetMovieInfoByID(id string) (info *model.MovieInfo, err error) {
 	return pcl.GetMovieInfoByURL(fmt.Sprintf(movieURL, url.QueryEscape(id)))
 }
@@ -3,6 +3,7 @@ package provider
 import (
 	"net/http"
 	"net/url"
+	"regexp"
 	"time"
 
 	"golang.org/x/text/language"
@@ -35,6 +36,12 @@ type MovieSearcher interface {
 	NormalizeMovieKeyword(Keyword string) string
 }
 
+type MovieNumberRouter interface {
+	// MovieNumberPatterns returns the patterns of movie numbers served by
+	// the provider, searches of matched numbers are routed to it only.
+	MovieNumberPatterns() []*regexp.Regexp
+}
+
 type MovieReviewer interface {
 	// GetMovieReviewsByID gets the user reviews of given movie id.
 	GetMovieReviewsByID(id string) ([]*model.MovieReviewDetail, error)
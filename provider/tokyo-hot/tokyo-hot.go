@@ -22,8 +22,9 @@ import (
 )
 
 var (
-	_ provider.MovieProvider = (*TokyoHot)(nil)
-	_ provider.MovieSearcher = (*TokyoHot)(nil)
+	_ provider.MovieProvider     = (*TokyoHot)(nil)
+	_ provider.MovieSearcher     = (*TokyoHot)(nil)
+	_ provider.MovieNumberRouter = (*TokyoHot)(nil)
 )
 
 const (
@@ -215,6 +216,14 @@ func (tht *TokyoHot) NormalizeMovieKeyword(keyword string) string {
 	return ""
 }
 
+var numberPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^(?i)(?:cz|gedo|k|n|kb|se)\d{2,4}$`),
+}
+
+func (tht *TokyoHot) MovieNumberPatterns() []*regexp.Regexp {
+	return numberPatterns
+}
+
 func (tht *TokyoHot) SearchMovie(keyword string) (results []*model.MovieSearchResult, err error) {
 	c := tht.ClonedCollector()
 
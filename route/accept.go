@@ -0,0 +1,92 @@
+package route
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/metatube-community/metatube-sdk-go/imageutil"
+)
+
+// imageFormatPreferences is the server-side preference order of
+// image formats. JPEG goes first since it is the most compatible
+// format for wildcard accepts, AVIF goes last as it is the most
+// expensive format to encode.
+var imageFormatPreferences = []string{
+	imageutil.JPEG,
+	imageutil.WebP,
+	imageutil.AVIF,
+	imageutil.PNG,
+}
+
+// negotiateImageFormat chooses an image format from the Accept header
+// by quality value first, then by specificity and server preference.
+func negotiateImageFormat(accept string) string {
+	if strings.TrimSpace(accept) == "" {
+		return imageutil.JPEG
+	}
+
+	type acceptRange struct {
+		typ, subtype string
+		q            float64
+	}
+	var ranges []acceptRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaRange, params, _ := strings.Cut(part, ";")
+		typ, subtype, found := strings.Cut(strings.TrimSpace(mediaRange), "/")
+		if !found {
+			continue
+		}
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(key, "q") {
+				if v, err := strconv.ParseFloat(value, 64); err == nil {
+					q = v
+				}
+			}
+		}
+		ranges = append(ranges, acceptRange{
+			typ:     strings.ToLower(typ),
+			subtype: strings.ToLower(subtype),
+			q:       q,
+		})
+	}
+
+	var (
+		best            string
+		bestQ           float64
+		bestSpecificity = -1
+	)
+	for _, format := range imageFormatPreferences {
+		mimeType, ok := imageutil.FormatMIMEType(format)
+		if !ok {
+			continue
+		}
+		typ, subtype, _ := strings.Cut(mimeType, "/")
+		// find the most specific range that matches this format.
+		q, specificity := 0.0, -1
+		for _, r := range ranges {
+			var s int
+			switch {
+			case r.typ == typ && r.subtype == subtype:
+				s = 2
+			case r.typ == typ && r.subtype == "*":
+				s = 1
+			case r.typ == "*" && r.subtype == "*":
+				s = 0
+			default:
+				continue
+			}
+			if s > specificity {
+				q, specificity = r.q, s
+			}
+		}
+		if specificity < 0 || q <= 0 {
+			continue // not acceptable.
+		}
+		if q > bestQ || (q == bestQ && specificity > bestSpecificity) {
+			best, bestQ, bestSpecificity = format, q, specificity
+		}
+	}
+	return best
+}
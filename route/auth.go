@@ -26,3 +26,30 @@ func authentication(v auth.Validator) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// authenticationUnlessSigned skips authentication for requests
+// with a verified signature, see verifySignature.
+func authenticationUnlessSigned(v auth.Validator) gin.HandlerFunc {
+	authenticate := authentication(v)
+	return func(c *gin.Context) {
+		if isSigned(c) {
+			c.Next()
+			return
+		}
+		authenticate(c)
+	}
+}
+
+// authenticationIf authenticates the requests that cond reports true,
+// e.g., requests of costly features on public routes, the requests with
+// a verified signature are trusted.
+func authenticationIf(v auth.Validator, cond func(*gin.Context) bool) gin.HandlerFunc {
+	authenticate := authenticationUnlessSigned(v)
+	return func(c *gin.Context) {
+		if !cond(c) {
+			c.Next()
+			return
+		}
+		authenticate(c)
+	}
+}
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigner(t *testing.T) {
+	signer := NewSigner("secret")
+	now := time.Unix(1700000000, 0)
+
+	u, err := url.Parse("/v1/images/primary/FANZA/abc123?url=https://pics.dmm.co.jp/a.jpg&pos=0.5")
+	require.NoError(t, err)
+
+	signed := signer.Sign(u, now.Add(time.Hour))
+	assert.NoError(t, signer.Verify(signed, now))
+	assert.ErrorIs(t, signer.Verify(signed, now.Add(2*time.Hour)), ErrSignatureExpired)
+	assert.ErrorIs(t, signer.Verify(u, now), ErrSignatureMissing)
+
+	// signing twice yields the same url.
+	assert.Equal(t, signed.String(), signer.Sign(signed, now.Add(time.Hour)).String())
+
+	for _, tamper := range []func(q url.Values){
+		func(q url.Values) { q.Set("url", "https://example.com/a.jpg") },
+		func(q url.Values) { q.Set("pos", "0.6") },
+		func(q url.Values) { q.Set(ExpiresQueryKey, "9999999999") },
+		func(q url.Values) { q.Add("badge", "zimu.png") },
+	} {
+		tampered := *signed
+		query := tampered.Query()
+		tamper(query)
+		tampered.RawQuery = query.Encode()
+		assert.ErrorIs(t, signer.Verify(&tampered, now), ErrSignatureInvalid)
+	}
+
+	moved := *signed
+	moved.Path = "/v1/images/primary/FANZA/other"
+	assert.ErrorIs(t, signer.Verify(&moved, now), ErrSignatureInvalid)
+
+	assert.ErrorIs(t, NewSigner("other").Verify(signed, now), ErrSignatureInvalid)
+}
@@ -22,3 +22,10 @@ func cacheNoStore() gin.HandlerFunc {
 		NoStore: true,
 	})
 }
+
+// cachePublicUntil is like cachePublicSMaxAge, but never caches beyond
+// the deadline, e.g., the expiry of a signed URL.
+func cachePublicUntil(c *gin.Context, deadline time.Time) {
+	duration := max(time.Until(deadline).Truncate(time.Second), 0)
+	cachePublicSMaxAge(duration)(c)
+}
@@ -1,6 +1,7 @@
 package route
 
 import (
+	goerr "errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -22,3 +23,57 @@ func getDBVersion(app *engine.Engine) gin.HandlerFunc {
 		})
 	}
 }
+
+func getDuplicateMovies(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		duplicates, err := app.GetDuplicateMovies()
+		if err != nil {
+			abortWithStatusMessage(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: duplicates})
+	}
+}
+
+type backfillQuery struct {
+	Limit int `form:"limit" binding:"min=1,max=100000"`
+}
+
+func backfillMovieCoverHashes(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := &backfillQuery{
+			Limit: 1000,
+		}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		if err := app.StartBackfillMovieCoverHashes(query.Limit); err != nil {
+			if goerr.Is(err, engine.ErrJobInProgress) {
+				abortWithStatusMessage(c, http.StatusConflict, err)
+				return
+			}
+			abortWithStatusMessage(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.JSON(http.StatusAccepted, &responseMessage{
+			Data: gin.H{
+				"limit": query.Limit,
+			},
+		})
+	}
+}
+
+func precomputeMovieCropPositions(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := app.StartPrecomputeMovieCropPositions(); err != nil {
+			if goerr.Is(err, engine.ErrJobInProgress) {
+				abortWithStatusMessage(c, http.StatusConflict, err)
+				return
+			}
+			abortWithStatusMessage(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.Status(http.StatusAccepted)
+	}
+}
@@ -0,0 +1,62 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/errors"
+)
+
+type debugImageQuery struct {
+	encodeQuery
+	Ratio    float64 `form:"ratio"`
+	Position float64 `form:"pos"`
+}
+
+func getDebugPrimaryImage(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uri := &imageUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		query := &debugImageQuery{
+			Ratio:       -1,
+			Position:    -1,
+			encodeQuery: encodeQuery{Quality: defaultImageQuality},
+		}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+
+		// format=json returns the details instead of the annotated image.
+		isJSON := query.Format == "json"
+		if !isJSON {
+			if err := query.negotiate(c); err != nil {
+				abortWithError(c, err)
+				return
+			}
+		}
+
+		if !app.IsMovieProvider(uri.Provider) {
+			abortWithError(c, errors.New(http.StatusBadRequest,
+				"only movie provider is supported"))
+			return
+		}
+
+		img, info, err := app.DebugMoviePrimaryImage(uri.AsProviderID(), query.Ratio, query.Position)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		if isJSON {
+			c.JSON(http.StatusOK, &responseMessage{Data: info})
+			return
+		}
+		renderImage(c, info.Detection.Annotate(img, info.Crop), &query.encodeQuery)
+	}
+}
@@ -1,17 +1,18 @@
 package route
 
 import (
-	"bytes"
+	goerr "errors"
+	"fmt"
 	"image"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/gin-gonic/gin/render"
 
 	R "github.com/metatube-community/metatube-sdk-go/constant"
 	"github.com/metatube-community/metatube-sdk-go/engine"
-	"github.com/metatube-community/metatube-sdk-go/imageutil"
+	"github.com/metatube-community/metatube-sdk-go/errors"
 	"github.com/metatube-community/metatube-sdk-go/imageutil/badge"
 	mt "github.com/metatube-community/metatube-sdk-go/provider"
 )
@@ -29,15 +30,69 @@ type imageUri struct {
 }
 
 type imageQuery struct {
+	resizeQuery
+	encodeQuery
 	URL      string  `form:"url"`
 	Ratio    float64 `form:"ratio"`
 	Position float64 `form:"pos"`
-	Auto     bool    `form:"auto"`
-	Badge    string  `form:"badge"`
-	Quality  int     `form:"quality"`
+	Auto     string  `form:"auto"`
+	// Badge specs, or "auto" to derive badges from metadata.
+	Badge []string `form:"badge"`
+	// Trim uniform-color borders, e.g., letterbox bars, before cropping,
+	// on by default except for primary images, trim=0 turns it off.
+	Trim bool `form:"trim"`
+	// Best picks the best cover among all providers, it requires
+	// authentication or a signature, see isBestCoverRequest.
+	Best bool `form:"best"`
+
+	// parsed badge specs.
+	badges    []*badge.Spec
+	autoBadge bool
+}
+
+const autoBadge = "auto"
+
+func isBestCoverRequest(c *gin.Context) bool {
+	best, _ := strconv.ParseBool(c.Query("best"))
+	return best
+}
+
+func (q *imageQuery) validate() error {
+	if err := q.resizeQuery.validate(); err != nil {
+		return err
+	}
+	if _, err := strconv.ParseBool(q.Auto); err != nil &&
+		q.Auto != "" && !strings.EqualFold(q.Auto, "saliency") {
+		return fmt.Errorf("invalid auto mode: %s", q.Auto)
+	}
+	q.badges = q.badges[:0]
+	for _, s := range q.Badge {
+		if strings.EqualFold(s, autoBadge) {
+			q.autoBadge = true
+			continue
+		}
+		spec, err := badge.Parse(s)
+		if err != nil {
+			return err
+		}
+		q.badges = append(q.badges, spec)
+	}
+	return nil
+}
+
+// cropMode parses the auto query, which is either
+// a boolean for face detection or "saliency".
+func (q *imageQuery) cropMode() engine.CropMode {
+	if strings.EqualFold(q.Auto, "saliency") {
+		return engine.SaliencyCrop
+	}
+	if auto, _ := strconv.ParseBool(q.Auto); auto {
+		return engine.FaceCrop
+	}
+	return engine.FixedCrop
 }
 
-func getImage(app *engine.Engine, typ imageType) gin.HandlerFunc {
+func getImage(app *engine.Engine, cfg *config, typ imageType) gin.HandlerFunc {
 	var ratio float64
 	switch typ {
 	case primaryImageType:
@@ -57,14 +112,25 @@ func getImage(app *engine.Engine, typ imageType) gin.HandlerFunc {
 			return
 		}
 		query := &imageQuery{
-			Ratio:    -1,
-			Position: -1,
-			Quality:  90,
+			Ratio:       -1,
+			Position:    -1,
+			encodeQuery: encodeQuery{Quality: defaultImageQuality},
+			// trim borders by default except for primary images.
+			Trim: typ != primaryImageType,
 		}
 		if err := c.ShouldBindQuery(query); err != nil {
 			abortWithStatusMessage(c, http.StatusBadRequest, err)
 			return
 		}
+		if err := query.validate(); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := query.negotiate(c); err != nil {
+			abortWithError(c, err)
+			return
+		}
 
 		// TODO: how to handle providers that implement
 		//   both actor and movie provider interfaces?
@@ -80,9 +146,16 @@ func getImage(app *engine.Engine, typ imageType) gin.HandlerFunc {
 		}
 
 		var (
-			img image.Image
-			err error
+			img  image.Image
+			err  error
+			opts = []engine.ImageOption{
+				engine.WithBorderTrim(query.Trim),
+				engine.WithBestCover(query.Best),
+			}
 		)
+		if query.Auto != "" /* default crop mode otherwise */ {
+			opts = append(opts, engine.WithCropMode(query.cropMode()))
+		}
 		if query.URL != "" /* specified URL */ {
 			var provider mt.Provider
 			if isActorProvider {
@@ -90,11 +163,16 @@ func getImage(app *engine.Engine, typ imageType) gin.HandlerFunc {
 			} else {
 				provider = app.MustGetMovieProviderByName(uri.Provider)
 			}
+			// signed requests are trusted regardless of the host.
+			if cfg.hostAllowlist && !isSigned(c) && !isAllowedProviderHost(provider, query.URL) {
+				abortWithStatusMessage(c, http.StatusForbidden, "image host not allowed")
+				return
+			}
 			// query.Ratio should apply only to the primary images.
 			if typ != primaryImageType || query.Ratio < 0 {
 				query.Ratio = ratio
 			}
-			img, err = app.GetImageByURL(provider, query.URL, query.Ratio, query.Position, query.Auto)
+			img, err = app.GetImageByURL(provider, query.URL, query.Ratio, query.Position, false, opts...)
 		} else if isActorProvider /* actor */ {
 			switch typ {
 			case primaryImageType:
@@ -106,11 +184,11 @@ func getImage(app *engine.Engine, typ imageType) gin.HandlerFunc {
 		} else /* movie */ {
 			switch typ {
 			case primaryImageType:
-				img, err = app.GetMoviePrimaryImage(uri.AsProviderID(), query.Ratio, query.Position)
+				img, err = app.GetMoviePrimaryImage(uri.AsProviderID(), query.Ratio, query.Position, opts...)
 			case thumbImageType:
-				img, err = app.GetMovieThumbImage(uri.AsProviderID())
+				img, err = app.GetMovieThumbImage(uri.AsProviderID(), opts...)
 			case backdropImageType:
-				img, err = app.GetMovieBackdropImage(uri.AsProviderID())
+				img, err = app.GetMovieBackdropImage(uri.AsProviderID(), opts...)
 			}
 		}
 		if err != nil {
@@ -118,27 +196,64 @@ func getImage(app *engine.Engine, typ imageType) gin.HandlerFunc {
 			return
 		}
 
-		if query.Badge != "" {
-			if img, err = badge.Badge(img, query.Badge); err != nil {
-				abortWithError(c, err)
+		// resize before badging to keep badges sharp.
+		img = query.resize(img)
+
+		badges := query.badges
+		if query.autoBadge && !isActorProvider {
+			// metadata errors should not fail the image.
+			if info, err := app.GetMovieInfoByProviderID(uri.AsProviderID(), true); err == nil {
+				badges = append(badge.FromNumber(info.Number), badges...)
+			}
+		}
+		if img, err = badge.Render(img, badges...); err != nil {
+			if goerr.Is(err, badge.ErrInvalidBadge) {
+				abortWithStatusMessage(c, http.StatusBadRequest, err)
 				return
 			}
+			abortWithError(c, err)
+			return
 		}
 
-		c.Header("X-MetaTube-Image-Width", strconv.Itoa(img.Bounds().Dx()))
-		c.Header("X-MetaTube-Image-Height", strconv.Itoa(img.Bounds().Dy()))
+		renderImage(c, img, &query.encodeQuery)
+	}
+}
 
-		buf := &bytes.Buffer{}
-		if err = imageutil.EncodeToJPEG(buf, img, query.Quality); err != nil {
-			panic(err)
+func getActorImageFromMovie(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uri := &imageUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		query := &renderQuery{
+			encodeQuery: encodeQuery{Quality: defaultImageQuality},
+		}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		if err := query.validate(); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		if err := query.negotiate(c); err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		if !app.IsMovieProvider(uri.Provider) {
+			abortWithError(c, errors.New(http.StatusBadRequest,
+				"only movie provider is supported"))
+			return
 		}
 
-		c.Render(http.StatusOK, render.Reader{
-			ContentType:   jpegImageMIMEType,
-			ContentLength: int64(buf.Len()),
-			Reader:        buf,
-		})
+		img, err := app.GetActorImageFromMovie(uri.AsProviderID())
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		renderImage(c, query.resize(img), &query.encodeQuery)
 	}
 }
-
-const jpegImageMIMEType = "image/jpeg"
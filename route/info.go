@@ -26,10 +26,12 @@ func (uri *infoUri) AsProviderID() providerid.ProviderID {
 }
 
 type infoQuery struct {
+	metadataTranslateQuery
+
 	Lazy bool `form:"lazy"`
 }
 
-func getInfo(app *engine.Engine, typ infoType) gin.HandlerFunc {
+func getInfo(app *engine.Engine, cfg *config, typ infoType) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		uri := &infoUri{}
 		if err := c.ShouldBindUri(uri); err != nil {
@@ -61,6 +63,11 @@ func getInfo(app *engine.Engine, typ infoType) gin.HandlerFunc {
 			return
 		}
 
+		if info, err = translateMetadata(cfg, info, query.Translate); err != nil {
+			abortWithError(c, err)
+			return
+		}
+
 		c.JSON(http.StatusOK, &responseMessage{Data: info})
 	}
 }
@@ -0,0 +1,54 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/common/number"
+)
+
+type numberQuery struct {
+	Filename string `form:"filename" binding:"required"`
+}
+
+type numberExplanation struct {
+	Filename string         `json:"filename"`
+	Number   string         `json:"number"`
+	Steps    []*number.Step `json:"steps"`
+}
+
+func explainNumber() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := &numberQuery{}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		num, steps := number.Explain(query.Filename)
+		c.JSON(http.StatusOK, &responseMessage{
+			Data: &numberExplanation{
+				Filename: query.Filename,
+				Number:   num,
+				Steps:    steps,
+			},
+		})
+	}
+}
+
+func getNumberRules() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, &responseMessage{Data: number.CurrentRules().Rules()})
+	}
+}
+
+func parseFilename() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := &numberQuery{}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: number.Parse(query.Filename)})
+	}
+}
@@ -0,0 +1,84 @@
+package route
+
+import (
+	"github.com/metatube-community/metatube-sdk-go/collection/maps"
+	"github.com/metatube-community/metatube-sdk-go/route/auth"
+	"github.com/metatube-community/metatube-sdk-go/translate"
+)
+
+type config struct {
+	// Image URL signer, nil if disabled.
+	signer *auth.Signer
+	// Reject image requests with unsigned url query.
+	rejectUnsigned bool
+	// Restrict url query to the hosts of its provider.
+	hostAllowlist bool
+	// Translation cache, nil if disabled.
+	translateCache *translate.Cache
+	// Translation glossary, nil if disabled.
+	glossary *translate.Glossary
+	// Named translator chains, used in place of engines.
+	translateChains *maps.CaseInsensitiveMap[*translate.Chain]
+	// Named server-side translator profiles.
+	translateProfiles *maps.CaseInsensitiveMap[translate.Translator]
+	// Allow translator credentials in url query.
+	allowRawCredentials bool
+	// Server-side metadata translator, nil if disabled.
+	metadataTranslator *translate.MetadataTranslator
+}
+
+type Option func(*config)
+
+func WithImageSigner(signer *auth.Signer) Option {
+	return func(c *config) {
+		c.signer = signer
+	}
+}
+
+func WithRejectUnsignedImageURL(v bool) Option {
+	return func(c *config) {
+		c.rejectUnsigned = v
+	}
+}
+
+func WithImageHostAllowlist(v bool) Option {
+	return func(c *config) {
+		c.hostAllowlist = v
+	}
+}
+
+func WithTranslateCache(cache *translate.Cache) Option {
+	return func(c *config) {
+		c.translateCache = cache
+	}
+}
+
+func WithMetadataTranslator(mt *translate.MetadataTranslator) Option {
+	return func(c *config) {
+		c.metadataTranslator = mt
+	}
+}
+
+func WithTranslateGlossary(glossary *translate.Glossary) Option {
+	return func(c *config) {
+		c.glossary = glossary
+	}
+}
+
+func WithTranslateChain(name string, chain *translate.Chain) Option {
+	return func(c *config) {
+		c.translateChains.Set(name, chain)
+	}
+}
+
+func WithTranslateProfile(name string, t translate.Translator) Option {
+	return func(c *config) {
+		c.translateProfiles.Set(name, t)
+	}
+}
+
+func WithTranslateRawCredentials(v bool) Option {
+	return func(c *config) {
+		c.allowRawCredentials = v
+	}
+}
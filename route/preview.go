@@ -0,0 +1,115 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/errors"
+)
+
+type previewUri struct {
+	infoUri
+	Index int `uri:"index"`
+}
+
+// renderQuery is the common query of images without cropping.
+type renderQuery struct {
+	resizeQuery
+	encodeQuery
+}
+
+func getPreviewImage(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uri := &previewUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		query := &renderQuery{
+			encodeQuery: encodeQuery{Quality: defaultImageQuality},
+		}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		if err := query.validate(); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		if err := query.negotiate(c); err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		if !app.IsMovieProvider(uri.Provider) {
+			abortWithError(c, errors.New(http.StatusBadRequest,
+				"only movie provider is supported"))
+			return
+		}
+
+		img, err := app.GetMoviePreviewImage(uri.AsProviderID(), uri.Index)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		renderImage(c, query.resize(img), &query.encodeQuery)
+	}
+}
+
+type spriteQuery struct {
+	encodeQuery
+	Columns int `form:"columns"`
+	Tile    int `form:"tile"`
+}
+
+func getPreviewSprite(app *engine.Engine) gin.HandlerFunc {
+	const (
+		maxColumns = 10
+		maxTile    = 640
+	)
+	return func(c *gin.Context) {
+		uri := &imageUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		query := &spriteQuery{
+			encodeQuery: encodeQuery{Quality: defaultImageQuality},
+			Columns:     4,
+			Tile:        320,
+		}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		if query.Columns <= 0 || query.Columns > maxColumns {
+			abortWithStatusMessage(c, http.StatusBadRequest, "invalid columns")
+			return
+		}
+		if query.Tile <= 0 || query.Tile > maxTile {
+			abortWithStatusMessage(c, http.StatusBadRequest, "invalid tile size")
+			return
+		}
+		if err := query.negotiate(c); err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		if !app.IsMovieProvider(uri.Provider) {
+			abortWithError(c, errors.New(http.StatusBadRequest,
+				"only movie provider is supported"))
+			return
+		}
+
+		img, err := app.GetMoviePreviewSprite(uri.AsProviderID(), query.Columns, snapImageSize(query.Tile))
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		renderImage(c, img, &query.encodeQuery)
+	}
+}
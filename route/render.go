@@ -0,0 +1,57 @@
+package route
+
+import (
+	"bytes"
+	"image"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+
+	"github.com/metatube-community/metatube-sdk-go/errors"
+	"github.com/metatube-community/metatube-sdk-go/imageutil"
+)
+
+const defaultImageQuality = 90
+
+type encodeQuery struct {
+	Quality  int    `form:"quality"`
+	Format   string `form:"format"`
+	Lossless bool   `form:"lossless"`
+}
+
+// negotiate resolves the output format from the format query or
+// the Accept header, it should be called before any heavy work.
+func (q *encodeQuery) negotiate(c *gin.Context) error {
+	if q.Format == "" /* content negotiation */ {
+		c.Header("Vary", "Accept")
+		if q.Format = negotiateImageFormat(c.GetHeader("Accept")); q.Format == "" {
+			return errors.FromCode(http.StatusNotAcceptable)
+		}
+	}
+	if _, ok := imageutil.FormatMIMEType(q.Format); !ok {
+		return errors.New(http.StatusBadRequest, "unsupported image format")
+	}
+	return nil
+}
+
+func renderImage(c *gin.Context, img image.Image, q *encodeQuery) {
+	c.Header("X-MetaTube-Image-Width", strconv.Itoa(img.Bounds().Dx()))
+	c.Header("X-MetaTube-Image-Height", strconv.Itoa(img.Bounds().Dy()))
+
+	buf := &bytes.Buffer{}
+	if err := imageutil.Encode(buf, img, q.Format, &imageutil.EncodeOptions{
+		Quality:  q.Quality,
+		Lossless: q.Lossless,
+	}); err != nil {
+		panic(err)
+	}
+
+	mimeType, _ := imageutil.FormatMIMEType(q.Format)
+	c.Render(http.StatusOK, render.Reader{
+		ContentType:   mimeType,
+		ContentLength: int64(buf.Len()),
+		Reader:        buf,
+	})
+}
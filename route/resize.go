@@ -0,0 +1,90 @@
+package route
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+
+	"github.com/metatube-community/metatube-sdk-go/imageutil"
+)
+
+// imageSizeBuckets are srcset-friendly sizes, any requested size
+// is rounded up to the nearest bucket, so that only a limited number
+// of image variants exist in caches.
+var imageSizeBuckets = []int{
+	80, 120, 160, 240, 320, 480, 640, 800,
+	960, 1280, 1600, 1920, 2560, 3840,
+}
+
+var maxImageSize = imageSizeBuckets[len(imageSizeBuckets)-1]
+
+func snapImageSize(v int) int {
+	if v <= 0 {
+		return 0
+	}
+	for _, bucket := range imageSizeBuckets {
+		if v <= bucket {
+			return bucket
+		}
+	}
+	return maxImageSize
+}
+
+// snapImageBox snaps the larger dimension of the requested box to the
+// buckets and derives the other one from the requested aspect ratio,
+// the box is then scaled down to the source bounds to avoid upscaling.
+func snapImageBox(width, height int, bounds image.Rectangle) (int, int) {
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	switch {
+	case width == 0 && height == 0:
+		return 0, 0
+	case height == 0:
+		return min(snapImageSize(width), srcWidth), 0
+	case width == 0:
+		return 0, min(snapImageSize(height), srcHeight)
+	}
+	w, h := float64(snapImageSize(width)), float64(snapImageSize(height))
+	if width >= height {
+		h = w * float64(height) / float64(width)
+	} else {
+		w = h * float64(width) / float64(height)
+	}
+	if scale := min(float64(srcWidth)/w, float64(srcHeight)/h); scale < 1 {
+		w, h = w*scale, h*scale
+	}
+	return max(int(math.Round(w)), 1), max(int(math.Round(h)), 1)
+}
+
+type resizeQuery struct {
+	Width  int    `form:"w"`
+	Height int    `form:"h"`
+	Fit    string `form:"fit"`
+	Filter string `form:"filter"`
+}
+
+func (q *resizeQuery) validate() error {
+	if q.Width < 0 || q.Height < 0 {
+		return fmt.Errorf("invalid image size: %dx%d", q.Width, q.Height)
+	}
+	switch strings.ToLower(q.Fit) {
+	case "", imageutil.FitCover, imageutil.FitContain, imageutil.FitFill:
+	default:
+		return fmt.Errorf("invalid fit mode: %s", q.Fit)
+	}
+	if _, ok := imageutil.ParseResampleFilter(q.Filter); !ok {
+		return fmt.Errorf("invalid resample filter: %s", q.Filter)
+	}
+	return nil
+}
+
+// resize resizes the image if any size is requested, it assumes
+// the query has been validated.
+func (q *resizeQuery) resize(img image.Image) image.Image {
+	if q.Width == 0 && q.Height == 0 {
+		return img
+	}
+	filter, _ := imageutil.ParseResampleFilter(q.Filter)
+	width, height := snapImageBox(q.Width, q.Height, img.Bounds())
+	return imageutil.ResizeFit(img, width, height, q.Fit, filter)
+}
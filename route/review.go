@@ -15,11 +15,13 @@ type reviewUri struct {
 }
 
 type reviewQuery struct {
+	metadataTranslateQuery
+
 	Homepage string `form:"homepage"`
 	Lazy     bool   `form:"lazy"`
 }
 
-func getReview(app *engine.Engine) gin.HandlerFunc {
+func getReview(app *engine.Engine, cfg *config) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		uri := &reviewUri{}
 		if err := c.ShouldBindUri(uri); err != nil {
@@ -54,6 +56,13 @@ func getReview(app *engine.Engine) gin.HandlerFunc {
 			return
 		}
 
-		c.JSON(http.StatusOK, &responseMessage{Data: reviews.Reviews})
+		data, err := translateMetadata(cfg,
+			[]*model.MovieReviewDetail(reviews.Reviews), query.Translate)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, &responseMessage{Data: data})
 	}
 }
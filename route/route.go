@@ -9,13 +9,24 @@ import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 
+	"github.com/metatube-community/metatube-sdk-go/collection/maps"
 	"github.com/metatube-community/metatube-sdk-go/engine"
 	"github.com/metatube-community/metatube-sdk-go/errors"
 	V "github.com/metatube-community/metatube-sdk-go/internal/version"
 	"github.com/metatube-community/metatube-sdk-go/route/auth"
+	"github.com/metatube-community/metatube-sdk-go/translate"
 )
 
-func New(app *engine.Engine, v auth.Validator) *gin.Engine {
+func New(app *engine.Engine, v auth.Validator, opts ...Option) *gin.Engine {
+	cfg := &config{
+		translateChains:   maps.NewCaseInsensitiveMap[*translate.Chain](),
+		translateProfiles: maps.NewCaseInsensitiveMap[translate.Translator](),
+	}
+	// apply options.
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
 	r := gin.New()
 	{
 		// support CORS
@@ -37,6 +48,9 @@ func New(app *engine.Engine, v auth.Validator) *gin.Engine {
 	{
 		system.GET("/modules", getModules())
 		system.GET("/providers", getProviders(app))
+		system.GET("/number/explain", explainNumber())
+		system.GET("/number/rules", getNumberRules())
+		system.GET("/parse", parseFilename())
 	}
 
 	public := r.Group("/v1",
@@ -44,39 +58,87 @@ func New(app *engine.Engine, v auth.Validator) *gin.Engine {
 		// a long time, especially behind a CDN.
 		cachePublicSMaxAge(180*24*time.Hour))
 	{
-		public.GET("/translate", getTranslate())
+		translation := public.Group("/translate",
+			// server-side translators are charged to the server.
+			authenticationIf(v, isServerTranslateRequest(cfg)))
+		{
+			translation.GET("", getTranslate(cfg))
+			translation.POST("/batch", cacheNoStore(), batchTranslate(cfg))
+		}
 
-		images := public.Group("/images")
+		images := public.Group("/images", verifySignature(cfg),
+			// best covers are fetched from many providers.
+			authenticationIf(v, isBestCoverRequest))
 		{
-			images.GET("/primary/:provider/:id", getImage(app, primaryImageType))
-			images.GET("/thumb/:provider/:id", getImage(app, thumbImageType))
-			images.GET("/backdrop/:provider/:id", getImage(app, backdropImageType))
+			images.GET("/primary/:provider/:id", getImage(app, cfg, primaryImageType))
+			images.GET("/thumb/:provider/:id", getImage(app, cfg, thumbImageType))
+			images.GET("/backdrop/:provider/:id", getImage(app, cfg, backdropImageType))
+			images.GET("/preview/:provider/:id/:index", getPreviewImage(app))
+			// sprites fetch and decode many frames from upstream.
+			images.GET("/sprite/:provider/:id", authenticationUnlessSigned(v), getPreviewSprite(app))
+			images.GET("/actor-from-movie/:provider/:id", getActorImageFromMovie(app))
 		}
 	}
 
 	private := r.Group("/v1", authentication(v))
 	{
+		private.GET("/sign", cacheNoStore(), getSignedURL(cfg))
+
 		db := private.Group("/db")
 		{
 			db.GET("/version", getDBVersion(app))
+			db.GET("/movies/duplicates", getDuplicateMovies(app))
+			db.POST("/movies/hashes/backfill", backfillMovieCoverHashes(app))
+			db.POST("/movies/positions/precompute", precomputeMovieCropPositions(app))
+		}
+
+		translateCache := private.Group("/translate/cache", cacheNoStore())
+		{
+			translateCache.GET("", getTranslateCacheStats(cfg))
+			translateCache.DELETE("", purgeTranslateCache(cfg))
+		}
+
+		private.GET("/translate/chains", cacheNoStore(), getTranslateChains(cfg))
+
+		glossary := private.Group("/translate/glossary", cacheNoStore())
+		{
+			glossary.GET("", getTranslateGlossary(cfg))
+			glossary.PUT("", putTranslateGlossary(cfg))
+			glossary.DELETE("", deleteTranslateGlossary(cfg))
+			glossary.POST("/seed", seedTranslateGlossary(app, cfg))
 		}
 
 		actors := private.Group("/actors")
 		{
-			actors.GET("/:provider/:id", getInfo(app, actorInfoType))
-			actors.GET("/search", getSearch(app, actorSearchType))
+			actors.GET("/:provider/:id", getInfo(app, cfg, actorInfoType))
+			actors.GET("/search", getSearch(app, cfg, actorSearchType))
 		}
 
 		movies := private.Group("/movies")
 		{
-			movies.GET("/:provider/:id", getInfo(app, movieInfoType))
-			movies.GET("/search", getSearch(app, movieSearchType))
+			movies.GET("/:provider/:id", getInfo(app, cfg, movieInfoType))
+			movies.GET("/search", getSearch(app, cfg, movieSearchType))
+			movies.GET("/search/route", cacheNoStore(), getMovieSearchRoute(app))
+			movies.POST("/search/image", getImageSearch(app))
 		}
 
 		reviews := private.Group("/reviews")
 		{
-			reviews.GET("/:provider/:id", getReview(app))
+			reviews.GET("/:provider/:id", getReview(app, cfg))
 		}
+
+		debug := private.Group("/debug", cacheNoStore())
+		{
+			debug.GET("/images/primary/:provider/:id", getDebugPrimaryImage(app))
+		}
+	}
+
+	videos := r.Group("/v1/videos",
+		// segments of rewritten HLS playlists are signed,
+		// so that players can fetch them without tokens.
+		verifySignature(cfg), authenticationUnlessSigned(v))
+	{
+		videos.GET("/preview/:provider/:id", getPreviewVideo(app, cfg))
 	}
 
 	return r
@@ -1,6 +1,7 @@
 package route
 
 import (
+	goerr "errors"
 	"net/http"
 	pkgurl "net/url"
 
@@ -8,6 +9,7 @@ import (
 
 	"github.com/metatube-community/metatube-sdk-go/engine"
 	"github.com/metatube-community/metatube-sdk-go/errors"
+	"github.com/metatube-community/metatube-sdk-go/imageutil"
 	"github.com/metatube-community/metatube-sdk-go/model"
 )
 
@@ -19,12 +21,14 @@ const (
 )
 
 type searchQuery struct {
+	metadataTranslateQuery
+
 	Q        string `form:"q" binding:"required"`
 	Provider string `form:"provider"`
 	Fallback bool   `form:"fallback"`
 }
 
-func getSearch(app *engine.Engine, typ searchType) gin.HandlerFunc {
+func getSearch(app *engine.Engine, cfg *config, typ searchType) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		query := &searchQuery{
 			Fallback: true, // enable fallback by default.
@@ -92,6 +96,77 @@ func getSearch(app *engine.Engine, typ searchType) gin.HandlerFunc {
 			return
 		}
 
+		if results, err = translateMetadata(cfg, results, query.Translate); err != nil {
+			abortWithError(c, err)
+			return
+		}
+
 		c.JSON(http.StatusOK, &responseMessage{Data: results})
 	}
 }
+
+type searchRouteQuery struct {
+	Q string `form:"q" binding:"required"`
+}
+
+func getMovieSearchRoute(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := &searchRouteQuery{}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		route, err := app.RouteMovieSearch(query.Q)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: route})
+	}
+}
+
+type imageSearchQuery struct {
+	Distance int `form:"distance" binding:"min=0,max=64"`
+	Limit    int `form:"limit" binding:"min=1,max=100"`
+}
+
+func getImageSearch(app *engine.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := &imageSearchQuery{
+			Distance: imageutil.SimilarHashDistance,
+			Limit:    20,
+		}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+
+		file, err := c.FormFile("image")
+		if err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		f, err := file.Open()
+		if err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		defer f.Close()
+
+		img, _, err := imageutil.Decode(f)
+		if err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		matches, err := app.SearchMovieByImage(img, query.Distance, query.Limit)
+		if goerr.Is(err, engine.ErrCoverHashIndexNotReady) {
+			abortWithStatusMessage(c, http.StatusServiceUnavailable, err)
+			return
+		}
+		if err != nil {
+			abortWithStatusMessage(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: matches})
+	}
+}
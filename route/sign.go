@@ -0,0 +1,131 @@
+package route
+
+import (
+	goerr "errors"
+	"net/http"
+	pkgurl "net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/publicsuffix"
+
+	"github.com/metatube-community/metatube-sdk-go/errors"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+	"github.com/metatube-community/metatube-sdk-go/route/auth"
+)
+
+// signedContextKey marks requests with a verified signature.
+const signedContextKey = "signed"
+
+const (
+	defaultSignatureTTL = 24 * time.Hour
+	maxSignatureTTL     = 30 * 24 * time.Hour
+)
+
+func verifySignature(cfg *config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cfg.signer == nil /* signing disabled */ {
+			c.Next()
+			return
+		}
+		if c.Query(auth.SignatureQueryKey) != "" {
+			if err := cfg.signer.Verify(c.Request.URL, time.Now()); err != nil {
+				abortWithStatusMessage(c, http.StatusForbidden, err)
+				return
+			}
+			// Shared caches must not serve the response after the
+			// signature expires.
+			if expires, err := strconv.ParseInt(c.Query(auth.ExpiresQueryKey), 10, 64); err == nil {
+				cachePublicUntil(c, time.Unix(expires, 0))
+			}
+			c.Set(signedContextKey, true)
+		} else if cfg.rejectUnsigned && c.Query("url") != "" {
+			abortWithStatusMessage(c, http.StatusForbidden, auth.ErrSignatureMissing)
+			return
+		}
+		c.Next()
+	}
+}
+
+func isSigned(c *gin.Context) bool {
+	return c.GetBool(signedContextKey)
+}
+
+// isAllowedProviderHost reports whether the raw URL belongs to the same
+// registrable domain as the provider, e.g., pics.dmm.co.jp for fanza.
+func isAllowedProviderHost(provider mt.Provider, rawURL string) bool {
+	u, err := pkgurl.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	host, base := u.Hostname(), provider.URL().Hostname()
+	if strings.EqualFold(host, base) {
+		return true
+	}
+	hostDomain, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return false
+	}
+	baseDomain, err := publicsuffix.EffectiveTLDPlusOne(base)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(hostDomain, baseDomain)
+}
+
+type signQuery struct {
+	Path string        `form:"path" binding:"required"`
+	TTL  time.Duration `form:"ttl"`
+}
+
+type signResponse struct {
+	URL     string `json:"url"`
+	Expires int64  `json:"expires"`
+}
+
+func getSignedURL(cfg *config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cfg.signer == nil {
+			abortWithError(c, errors.New(http.StatusNotImplemented, "url signing is not enabled"))
+			return
+		}
+		query := &signQuery{
+			TTL: defaultSignatureTTL,
+		}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		if query.TTL <= 0 || query.TTL > maxSignatureTTL {
+			abortWithStatusMessage(c, http.StatusBadRequest, goerr.New("invalid ttl"))
+			return
+		}
+
+		u, err := pkgurl.ParseRequestURI(query.Path)
+		if err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		if u.IsAbs() || !strings.HasPrefix(u.Path, "/v1/images/") {
+			abortWithStatusMessage(c, http.StatusBadRequest, "only image paths can be signed")
+			return
+		}
+
+		// Round the expiry up to a full hour, so that the same image
+		// signed within an hour shares the same URL for better caching,
+		// but never beyond the maximum TTL.
+		now := time.Now()
+		expires := now.Add(query.TTL).Truncate(time.Hour).Add(time.Hour)
+		if limit := now.Add(maxSignatureTTL); expires.After(limit) {
+			expires = limit.Truncate(time.Hour)
+		}
+		c.JSON(http.StatusOK, &responseMessage{
+			Data: &signResponse{
+				URL:     cfg.signer.Sign(u, expires).String(),
+				Expires: expires.Unix(),
+			},
+		})
+	}
+}
@@ -1,11 +1,16 @@
 package route
 
 import (
+	goerr "errors"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/gorilla/schema"
 
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/errors"
+	"github.com/metatube-community/metatube-sdk-go/model"
 	"github.com/metatube-community/metatube-sdk-go/translate"
 	_ "github.com/metatube-community/metatube-sdk-go/translate/baidu"
 	_ "github.com/metatube-community/metatube-sdk-go/translate/deepl"
@@ -14,11 +19,18 @@ import (
 	_ "github.com/metatube-community/metatube-sdk-go/translate/openai"
 )
 
+// translatorQuery selects a server-side profile or chain by profile,
+// or an engine whose options are decoded from the url query.
+type translatorQuery struct {
+	Engine  string `form:"engine"`
+	Profile string `form:"profile"`
+}
+
 type translateQuery struct {
-	Q      string `form:"q" binding:"required"`
-	From   string `form:"from"`
-	To     string `form:"to" binding:"required"`
-	Engine string `form:"engine" binding:"required"`
+	translatorQuery
+	Q    string `form:"q" binding:"required"`
+	From string `form:"from"`
+	To   string `form:"to" binding:"required"`
 }
 
 type translateResponse struct {
@@ -27,11 +39,67 @@ type translateResponse struct {
 	Text string `json:"translated_text"`
 }
 
-func getTranslate() gin.HandlerFunc {
-	decoder := schema.NewDecoder()
-	decoder.SetAliasTag("json")
-	decoder.IgnoreUnknownKeys(true)
+var (
+	errTranslatorRequired = errors.New(http.StatusBadRequest, "engine or profile is required")
+	errTranslateProfile   = errors.New(http.StatusNotFound, "translate profile not found")
+	errRawCredentials     = errors.New(http.StatusForbidden,
+		"raw translator credentials are not allowed, use a translate profile instead")
+)
+
+// isServerTranslateRequest reports whether the translate request uses
+// the translators or credentials configured on the server, i.e., any
+// profile, chains by engine and engines with options, which require
+// authentication on public routes. Only credential-free engines like
+// googlefree are left public.
+func isServerTranslateRequest(cfg *config) func(*gin.Context) bool {
+	return func(c *gin.Context) bool {
+		if c.Query("profile") != "" {
+			return true
+		}
+		engine := c.Query("engine")
+		if _, ok := cfg.translateChains.Get(engine); ok {
+			return true
+		}
+		return translate.HasOptions(engine, c.Request.URL.Query())
+	}
+}
+
+// newTranslator returns the translator selected by the query, engines
+// of profiles and chains are cached individually.
+func newTranslator(c *gin.Context, cfg *config, query *translatorQuery) (translate.Translator, error) {
+	switch {
+	case query.Profile != "":
+		// never cache responses of authenticated requests.
+		c.Header("Cache-Control", "no-store")
+		if profile, ok := cfg.translateProfiles.Get(query.Profile); ok {
+			return cfg.glossary.Wrap(profile), nil
+		}
+		if chain, ok := cfg.translateChains.Get(query.Profile); ok {
+			return cfg.glossary.Wrap(chain), nil
+		}
+		return nil, errTranslateProfile
+	case query.Engine != "":
+		if chain, ok := cfg.translateChains.Get(query.Engine); ok {
+			// never cache responses of authenticated requests.
+			c.Header("Cache-Control", "no-store")
+			return cfg.glossary.Wrap(chain), nil
+		}
+		values := c.Request.URL.Query()
+		if translate.HasOptions(query.Engine, values) {
+			if !cfg.allowRawCredentials {
+				return nil, errRawCredentials
+			}
+			// never cache responses of authenticated requests.
+			c.Header("Cache-Control", "no-store")
+		}
+		return cfg.glossary.Wrap(cfg.translateCache.
+			Wrap(query.Engine, translate.NewWithValues(query.Engine, values))), nil
+	default:
+		return nil, errTranslatorRequired
+	}
+}
 
+func getTranslate(cfg *config) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		query := &translateQuery{
 			From: "auto",
@@ -41,13 +109,12 @@ func getTranslate() gin.HandlerFunc {
 			return
 		}
 
-		decode := func(v any) error {
-			return decoder.Decode(v, c.Request.URL.Query())
+		translator, err := newTranslator(c, cfg, &query.translatorQuery)
+		if err != nil {
+			abortWithError(c, err)
+			return
 		}
-
-		result, err := translate.
-			New(query.Engine, decode).
-			Translate(query.Q, query.From, query.To)
+		result, err := translator.Translate(query.Q, query.From, query.To)
 		if err != nil {
 			abortWithError(c, err)
 			return
@@ -62,3 +129,251 @@ func getTranslate() gin.HandlerFunc {
 		})
 	}
 }
+
+type batchTranslateQuery struct {
+	translatorQuery
+	From string `form:"from"`
+	To   string `form:"to" binding:"required"`
+}
+
+type batchTranslateBody struct {
+	Texts []string `json:"texts" binding:"required,min=1,max=100"`
+}
+
+type batchTranslateResponse struct {
+	From  string   `json:"from"`
+	To    string   `json:"to"`
+	Texts []string `json:"translated_texts"`
+}
+
+func batchTranslate(cfg *config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := &batchTranslateQuery{
+			From: "auto",
+		}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		body := &batchTranslateBody{}
+		if err := c.ShouldBindJSON(body); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+
+		translator, err := newTranslator(c, cfg, &query.translatorQuery)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		results, err := translate.TranslateBatch(translator, body.Texts, query.From, query.To)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, &responseMessage{
+			Data: &batchTranslateResponse{
+				From:  query.From,
+				To:    query.To,
+				Texts: results,
+			},
+		})
+	}
+}
+
+type translateChainResponse struct {
+	Name    string                   `json:"name"`
+	Engines []translate.EngineHealth `json:"engines"`
+}
+
+func getTranslateChains(cfg *config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		chains := make([]*translateChainResponse, 0, cfg.translateChains.Len())
+		for name, chain := range cfg.translateChains.Iterator() {
+			chains = append(chains, &translateChainResponse{
+				Name:    name,
+				Engines: chain.Health(),
+			})
+		}
+		slices.SortFunc(chains, func(a, b *translateChainResponse) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		c.JSON(http.StatusOK, &responseMessage{Data: chains})
+	}
+}
+
+var errTranslateCacheDisabled = goerr.New("translation cache disabled")
+
+func getTranslateCacheStats(cfg *config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cfg.translateCache == nil {
+			abortWithStatusMessage(c, http.StatusNotFound, errTranslateCacheDisabled)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: cfg.translateCache.Stats()})
+	}
+}
+
+type purgeTranslateCacheQuery struct {
+	Engine string `form:"engine"`
+}
+
+func purgeTranslateCache(cfg *config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cfg.translateCache == nil {
+			abortWithStatusMessage(c, http.StatusNotFound, errTranslateCacheDisabled)
+			return
+		}
+		query := &purgeTranslateCacheQuery{}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		if err := cfg.translateCache.Purge(query.Engine); err != nil {
+			abortWithStatusMessage(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+var errTranslateGlossaryDisabled = goerr.New("translation glossary disabled")
+
+type glossaryQuery struct {
+	Kind string `form:"kind"`
+	Q    string `form:"q"`
+}
+
+func getTranslateGlossary(cfg *config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cfg.glossary == nil {
+			abortWithStatusMessage(c, http.StatusNotFound, errTranslateGlossaryDisabled)
+			return
+		}
+		query := &glossaryQuery{}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		terms := make([]*translate.Term, 0)
+		for _, term := range cfg.glossary.Terms() {
+			if (query.Kind != "" && !strings.EqualFold(term.Kind, query.Kind)) ||
+				(query.Q != "" && !strings.Contains(term.Term, query.Q)) {
+				continue
+			}
+			terms = append(terms, term)
+		}
+		c.JSON(http.StatusOK, &responseMessage{Data: terms})
+	}
+}
+
+func putTranslateGlossary(cfg *config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cfg.glossary == nil {
+			abortWithStatusMessage(c, http.StatusNotFound, errTranslateGlossaryDisabled)
+			return
+		}
+		var terms []*translate.Term
+		if err := c.ShouldBindJSON(&terms); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		for _, term := range terms {
+			if term == nil || term.Term == "" {
+				abortWithStatusMessage(c, http.StatusBadRequest, "empty glossary term")
+				return
+			}
+		}
+		if err := cfg.glossary.Put(terms...); err != nil {
+			abortWithStatusMessage(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+type deleteGlossaryQuery struct {
+	Term string `form:"term" binding:"required"`
+	From string `form:"from"`
+	To   string `form:"to"`
+}
+
+func deleteTranslateGlossary(cfg *config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cfg.glossary == nil {
+			abortWithStatusMessage(c, http.StatusNotFound, errTranslateGlossaryDisabled)
+			return
+		}
+		query := &deleteGlossaryQuery{}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		if err := cfg.glossary.Delete(&translate.Term{
+			Term: query.Term,
+			From: query.From,
+			To:   query.To,
+		}); err != nil {
+			abortWithStatusMessage(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func seedTranslateGlossary(app *engine.Engine, cfg *config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cfg.glossary == nil {
+			abortWithStatusMessage(c, http.StatusNotFound, errTranslateGlossaryDisabled)
+			return
+		}
+		terms, err := app.GlossarySeedTerms()
+		if err != nil {
+			abortWithStatusMessage(c, http.StatusInternalServerError, err)
+			return
+		}
+		added, err := cfg.glossary.Seed(terms...)
+		if err != nil {
+			abortWithStatusMessage(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.JSON(http.StatusOK, &responseMessage{
+			Data: gin.H{
+				"added": added,
+			},
+		})
+	}
+}
+
+// metadataTranslateQuery requests server-side translation of metadata.
+type metadataTranslateQuery struct {
+	Translate string `form:"translate"`
+}
+
+var errMetadataTranslatorDisabled = errors.New(http.StatusBadRequest, "server-side translation is not configured")
+
+// translateMetadata translates the metadata into the language along with
+// the original values, data is returned as is if no language is given.
+func translateMetadata(cfg *config, data any, to string) (any, error) {
+	if to == "" {
+		return data, nil
+	}
+	mt := cfg.metadataTranslator
+	if mt == nil {
+		return nil, errMetadataTranslatorDisabled
+	}
+	switch v := data.(type) {
+	case *model.MovieInfo:
+		return mt.MovieInfo(v, to)
+	case *model.ActorInfo:
+		return mt.ActorInfo(v, to)
+	case []*model.MovieSearchResult:
+		return mt.MovieSearchResults(v, to)
+	case []*model.MovieReviewDetail:
+		return mt.MovieReviews(v, to)
+	default:
+		// nothing to translate, e.g., actor search results.
+		return data, nil
+	}
+}
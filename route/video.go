@@ -0,0 +1,151 @@
+package route
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	pkgurl "net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/gin-gonic/gin"
+
+	"github.com/metatube-community/metatube-sdk-go/common/m3u8"
+	"github.com/metatube-community/metatube-sdk-go/engine"
+	"github.com/metatube-community/metatube-sdk-go/errors"
+	"github.com/metatube-community/metatube-sdk-go/model"
+	mt "github.com/metatube-community/metatube-sdk-go/provider"
+)
+
+const (
+	hlsMIMEType         = "application/vnd.apple.mpegurl"
+	hlsSignatureTTL     = 6 * time.Hour
+	maxHLSPlaylistBytes = 4 * units.MiB
+)
+
+type videoQuery struct {
+	// URI is the absolute URI of a HLS sub-playlist or segment.
+	URI string `form:"uri"`
+	// HLS prefers HLS over the progressive video.
+	HLS bool `form:"hls"`
+}
+
+func getPreviewVideo(app *engine.Engine, cfg *config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uri := &infoUri{}
+		if err := c.ShouldBindUri(uri); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+		query := &videoQuery{}
+		if err := c.ShouldBindQuery(query); err != nil {
+			abortWithStatusMessage(c, http.StatusBadRequest, err)
+			return
+		}
+
+		if !app.IsMovieProvider(uri.Provider) {
+			abortWithError(c, errors.New(http.StatusBadRequest,
+				"only movie provider is supported"))
+			return
+		}
+		provider := app.MustGetMovieProviderByName(uri.Provider)
+
+		info, err := app.GetMovieInfoByProviderID(uri.AsProviderID(), true)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		target := query.URI
+		if target == "" {
+			switch {
+			case query.HLS && info.PreviewVideoHLSURL != "":
+				target = info.PreviewVideoHLSURL
+			case info.PreviewVideoURL != "":
+				target = info.PreviewVideoURL
+			case info.PreviewVideoHLSURL != "":
+				target = info.PreviewVideoHLSURL
+			default:
+				abortWithError(c, mt.ErrVideoNotFound)
+				return
+			}
+		} else if !isSigned(c) && !isAllowedVideoURL(provider, info, target) {
+			// signed URIs are issued by the playlist rewriting below.
+			abortWithStatusMessage(c, http.StatusForbidden, "video host not allowed")
+			return
+		}
+
+		resp, err := app.FetchStream(c.Request.Context(), target, c.Request.Header, provider)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		defer resp.Body.Close()
+
+		if !isHLSPlaylist(resp) {
+			extraHeaders := make(map[string]string)
+			for _, key := range []string{
+				"Accept-Ranges",
+				"Content-Range",
+				"ETag",
+				"Last-Modified",
+			} {
+				if value := resp.Header.Get(key); value != "" {
+					extraHeaders[key] = value
+				}
+			}
+			c.DataFromReader(resp.StatusCode, resp.ContentLength,
+				resp.Header.Get("Content-Type"), resp.Body, extraHeaders)
+			return
+		}
+
+		// Rewrite all playlist URIs to proxy through this route.
+		proxyURL := func(u *pkgurl.URL) string {
+			p := &pkgurl.URL{
+				Path:     c.Request.URL.Path,
+				RawQuery: pkgurl.Values{"uri": {u.String()}}.Encode(),
+			}
+			if cfg.signer != nil {
+				p = cfg.signer.Sign(p, time.Now().Add(hlsSignatureTTL))
+			}
+			return p.String()
+		}
+		base := resp.Request.URL // final URL after redirects.
+		buf := &bytes.Buffer{}
+		if err = m3u8.RewriteURIs(buf,
+			io.LimitReader(resp.Body, maxHLSPlaylistBytes),
+			base, proxyURL); err != nil {
+			abortWithStatusMessage(c, http.StatusBadGateway, err)
+			return
+		}
+		c.Data(http.StatusOK, hlsMIMEType, buf.Bytes())
+	}
+}
+
+func isHLSPlaylist(resp *http.Response) bool {
+	if strings.Contains(strings.ToLower(resp.Header.Get("Content-Type")), "mpegurl") {
+		return true
+	}
+	return strings.EqualFold(path.Ext(resp.Request.URL.Path), ".m3u8")
+}
+
+// isAllowedVideoURL reports whether the raw URL is served by the same hosts
+// as the preview videos of the movie, or belongs to the provider domain.
+func isAllowedVideoURL(provider mt.Provider, info *model.MovieInfo, rawURL string) bool {
+	u, err := pkgurl.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	for _, videoURL := range []string{
+		info.PreviewVideoURL,
+		info.PreviewVideoHLSURL,
+	} {
+		if v, err := pkgurl.Parse(videoURL); err == nil && videoURL != "" &&
+			strings.EqualFold(v.Hostname(), u.Hostname()) {
+			return true
+		}
+	}
+	return isAllowedProviderHost(provider, rawURL)
+}
@@ -0,0 +1,49 @@
+package translate
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrBatchSize = errors.New("translate: mismatched number of batch results")
+
+// BatchTranslator is optionally implemented by translators that can
+// translate multiple texts in a single request.
+type BatchTranslator interface {
+	Translator
+	TranslateBatch(texts []string, from, to string) ([]string, error)
+}
+
+// TranslateBatch translates the texts in order, within a single request
+// if t is a BatchTranslator, otherwise one by one.
+func TranslateBatch(t Translator, texts []string, from, to string) ([]string, error) {
+	if len(texts) == 0 {
+		return []string{}, nil
+	}
+	bt, ok := t.(BatchTranslator)
+	if !ok {
+		return TranslateEach(t, texts, from, to)
+	}
+	results, err := bt.TranslateBatch(texts, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(texts) {
+		return nil, fmt.Errorf("%w: expected %d, got %d", ErrBatchSize, len(texts), len(results))
+	}
+	return results, nil
+}
+
+// TranslateEach translates the texts one by one, it's the fallback
+// of translators without native batch support.
+func TranslateEach(t Translator, texts []string, from, to string) ([]string, error) {
+	results := make([]string, len(texts))
+	for i, text := range texts {
+		result, err := t.Translate(text, from, to)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = result
+	}
+	return results, nil
+}
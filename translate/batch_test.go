@@ -0,0 +1,67 @@
+package translate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type batchTranslator struct {
+	countingTranslator
+	batches [][]string
+}
+
+func (t *batchTranslator) TranslateBatch(texts []string, _, to string) ([]string, error) {
+	t.batches = append(t.batches, texts)
+	results := make([]string, len(texts))
+	for i, text := range texts {
+		results[i] = to + ":" + strings.ToUpper(text)
+	}
+	return results, nil
+}
+
+func TestTranslateBatch(t *testing.T) {
+	texts := []string{"a", "b", "c"}
+
+	// fallback to one by one.
+	tr := &countingTranslator{}
+	results, err := TranslateBatch(tr, texts, "en", "ja")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ja:A", "ja:B", "ja:C"}, results)
+	assert.Equal(t, 3, tr.calls)
+
+	bt := &batchTranslator{}
+	results, err = TranslateBatch(bt, texts, "en", "ja")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ja:A", "ja:B", "ja:C"}, results)
+	assert.Equal(t, [][]string{texts}, bt.batches)
+	assert.Zero(t, bt.calls)
+
+	results, err = TranslateBatch(bt, nil, "en", "ja")
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestTranslateBatchWrapped(t *testing.T) {
+	bt := &batchTranslator{}
+	cache := NewCache(NewMemoryCacheStore(0, 0))
+	g := NewGlossary()
+	g.AddTerms(
+		&Term{Term: "Yua Mikami", Protected: true},
+		&Term{Term: "drama", To: "ja", Translation: "ドラマ"},
+	)
+	tr := g.Wrap(cache.Wrap("test", bt))
+
+	_, err := tr.Translate("b", "en", "ja")
+	require.NoError(t, err)
+
+	results, err := TranslateBatch(tr, []string{"a", "b", "drama", "a drama of Yua Mikami"}, "en", "ja")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ja:A", "ja:B", "ドラマ", "ja:A ドラマ OF Yua Mikami"}, results)
+
+	// only texts neither cached nor terms are sent in one batch.
+	assert.Equal(t, [][]string{{"a", "a __T0__ of __T1__"}}, bt.batches)
+	assert.Equal(t, 1, bt.calls)
+}
@@ -0,0 +1,214 @@
+package translate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+)
+
+// Variant is optionally implemented by translators whose results vary
+// with options other than the languages, e.g., the model or the prompt.
+type Variant interface {
+	Variant() string
+}
+
+// CacheKey identifies a translation.
+type CacheKey struct {
+	Engine  string
+	Variant string
+	From    string
+	To      string
+	Text    string
+}
+
+// Hash returns the digest of the key, which is used to store the
+// translation, so that the source text is not stored.
+func (k CacheKey) Hash() string {
+	h := sha256.New()
+	for _, s := range []string{k.Engine, k.Variant, k.From, k.To, k.Text} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// CacheStore stores translations by cache keys.
+type CacheStore interface {
+	Get(key CacheKey) (string, bool)
+	Set(key CacheKey, text string) error
+	// Purge removes translations of the engine, or all if engine is empty.
+	Purge(engine string) error
+}
+
+// CacheStats is the hit metrics of a cache.
+type CacheStats struct {
+	Hits    uint64  `json:"hits"`
+	Misses  uint64  `json:"misses"`
+	HitRate float64 `json:"hit_rate"`
+}
+
+// Cache caches the translations of any translator.
+type Cache struct {
+	store  CacheStore
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+func NewCache(store CacheStore) *Cache {
+	return &Cache{store: store}
+}
+
+// Wrap returns a translator that caches the translations of t,
+// engine is the registered name of the translator.
+func (c *Cache) Wrap(engine string, t Translator) Translator {
+	if _, ok := t.(*errorTranslator); ok || c == nil {
+		return t
+	}
+	var variant string
+	if v, ok := t.(Variant); ok {
+		variant = v.Variant()
+	}
+	return &cachedTranslator{
+		cache:   c,
+		engine:  strings.ToLower(engine),
+		variant: variant,
+		t:       t,
+	}
+}
+
+// Stats returns the hit metrics since the cache is created.
+func (c *Cache) Stats() CacheStats {
+	stats := CacheStats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
+
+// Purge removes cached translations of the engine, or all if engine is empty.
+func (c *Cache) Purge(engine string) error {
+	return c.store.Purge(strings.ToLower(engine))
+}
+
+var _ BatchTranslator = (*cachedTranslator)(nil)
+
+type cachedTranslator struct {
+	cache   *Cache
+	engine  string
+	variant string
+	t       Translator
+}
+
+func (ct *cachedTranslator) key(text, from, to string) CacheKey {
+	return CacheKey{
+		Engine:  ct.engine,
+		Variant: ct.variant,
+		From:    from,
+		To:      to,
+		Text:    text,
+	}
+}
+
+func (ct *cachedTranslator) Translate(text, from, to string) (string, error) {
+	key := ct.key(text, from, to)
+	if result, ok := ct.cache.store.Get(key); ok {
+		ct.cache.hits.Add(1)
+		return result, nil
+	}
+	ct.cache.misses.Add(1)
+	result, err := ct.t.Translate(text, from, to)
+	if err != nil {
+		return "", err
+	}
+	_ = ct.cache.store.Set(key, result) // ignore error
+	return result, nil
+}
+
+// TranslateBatch translates the texts that are not cached in one batch.
+func (ct *cachedTranslator) TranslateBatch(texts []string, from, to string) ([]string, error) {
+	var (
+		results = make([]string, len(texts))
+		missed  []string
+		indexes []int
+	)
+	for i, text := range texts {
+		if result, ok := ct.cache.store.Get(ct.key(text, from, to)); ok {
+			ct.cache.hits.Add(1)
+			results[i] = result
+			continue
+		}
+		ct.cache.misses.Add(1)
+		missed = append(missed, text)
+		indexes = append(indexes, i)
+	}
+	if len(missed) == 0 {
+		return results, nil
+	}
+	translated, err := TranslateBatch(ct.t, missed, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for i, result := range translated {
+		results[indexes[i]] = result
+		_ = ct.cache.store.Set(ct.key(missed[i], from, to), result) // ignore error
+	}
+	return results, nil
+}
+
+var _ CacheStore = (*MemoryCacheStore)(nil)
+
+// MemoryCacheStore is an in-memory CacheStore with LRU eviction.
+type MemoryCacheStore struct {
+	cache *ttlcache.Cache[string, memoryCacheItem]
+}
+
+type memoryCacheItem struct {
+	engine string
+	text   string
+}
+
+// NewMemoryCacheStore creates a store that keeps at most capacity
+// translations for ttl, zero means no limit.
+func NewMemoryCacheStore(capacity uint64, ttl time.Duration) *MemoryCacheStore {
+	return &MemoryCacheStore{
+		cache: ttlcache.New[string, memoryCacheItem](
+			ttlcache.WithTTL[string, memoryCacheItem](ttl),
+			ttlcache.WithCapacity[string, memoryCacheItem](capacity),
+		),
+	}
+}
+
+func (s *MemoryCacheStore) Get(key CacheKey) (string, bool) {
+	if item := s.cache.Get(key.Hash()); item != nil {
+		return item.Value().text, true
+	}
+	return "", false
+}
+
+func (s *MemoryCacheStore) Set(key CacheKey, text string) error {
+	s.cache.Set(key.Hash(), memoryCacheItem{
+		engine: key.Engine,
+		text:   text,
+	}, ttlcache.DefaultTTL)
+	return nil
+}
+
+func (s *MemoryCacheStore) Purge(engine string) error {
+	if engine == "" {
+		s.cache.DeleteAll()
+		return nil
+	}
+	for hash, item := range s.cache.Items() {
+		if item.Value().engine == engine {
+			s.cache.Delete(hash)
+		}
+	}
+	return nil
+}
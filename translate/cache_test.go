@@ -0,0 +1,87 @@
+package translate
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingTranslator struct {
+	mu    sync.Mutex
+	calls int
+	err   error
+}
+
+func (t *countingTranslator) Translate(text, _, to string) (string, error) {
+	t.mu.Lock()
+	t.calls++
+	t.mu.Unlock()
+	if t.err != nil {
+		return "", t.err
+	}
+	return to + ":" + strings.ToUpper(text), nil
+}
+
+type variantTranslator struct {
+	countingTranslator
+	model string
+}
+
+func (t *variantTranslator) Variant() string { return t.model }
+
+func TestCache(t *testing.T) {
+	cache := NewCache(NewMemoryCacheStore(0, 0))
+
+	tr := &countingTranslator{}
+	ct := cache.Wrap("DeepL", tr)
+	for i := 0; i < 3; i++ {
+		result, err := ct.Translate("hello", "en", "ja")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "ja:HELLO", result)
+		}
+	}
+	assert.Equal(t, 1, tr.calls)
+
+	// different target language.
+	_, _ = ct.Translate("hello", "en", "de")
+	assert.Equal(t, 2, tr.calls)
+	assert.Equal(t, CacheStats{Hits: 2, Misses: 2, HitRate: 0.5}, cache.Stats())
+
+	// different variants do not share translations.
+	v1 := &variantTranslator{model: "m1"}
+	v2 := &variantTranslator{model: "m2"}
+	_, _ = cache.Wrap("openai", v1).Translate("hello", "en", "ja")
+	_, _ = cache.Wrap("openai", v2).Translate("hello", "en", "ja")
+	assert.Equal(t, 1, v1.calls)
+	assert.Equal(t, 1, v2.calls)
+
+	// errors are not cached.
+	et := &countingTranslator{err: errors.New("quota exceeded")}
+	for i := 0; i < 2; i++ {
+		_, err := cache.Wrap("google", et).Translate("hello", "en", "ja")
+		assert.Error(t, err)
+	}
+	assert.Equal(t, 2, et.calls)
+
+	// purge by engine.
+	assert.NoError(t, cache.Purge("deepl"))
+	_, _ = ct.Translate("hello", "en", "ja")
+	assert.Equal(t, 3, tr.calls)
+	_, _ = cache.Wrap("openai", v1).Translate("hello", "en", "ja")
+	assert.Equal(t, 1, v1.calls)
+
+	// purge all.
+	assert.NoError(t, cache.Purge(""))
+	_, _ = cache.Wrap("openai", v1).Translate("hello", "en", "ja")
+	assert.Equal(t, 2, v1.calls)
+}
+
+func TestCacheKeyHash(t *testing.T) {
+	a := CacheKey{Engine: "deepl", From: "en", To: "ja", Text: "ab"}
+	b := CacheKey{Engine: "deepl", From: "en", To: "jaa", Text: "b"}
+	assert.NotEqual(t, a.Hash(), b.Hash())
+	assert.Equal(t, a.Hash(), a.Hash())
+}
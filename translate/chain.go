@@ -0,0 +1,263 @@
+package translate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultChainTimeout is the max duration of each engine in a chain.
+	DefaultChainTimeout = 15 * time.Second
+	// DefaultChainCooldown is the duration an unhealthy engine is skipped.
+	DefaultChainCooldown = time.Minute
+	// maxConsecutiveFailures marks an engine unhealthy.
+	maxConsecutiveFailures = 3
+	// maxTimedOutCalls limits the timed-out calls of an engine that are
+	// still running, new calls fail fast until some of them return.
+	maxTimedOutCalls = 8
+)
+
+var ErrTimeout = errors.New("translate: timeout")
+
+// EngineHealth is the health of an engine in a chain.
+type EngineHealth struct {
+	Engine              string    `json:"engine"`
+	Healthy             bool      `json:"healthy"`
+	Successes           uint64    `json:"successes"`
+	Failures            uint64    `json:"failures"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastError           string    `json:"last_error,omitempty"`
+	LastFailure         time.Time `json:"last_failure,omitzero"`
+}
+
+type chainEngine struct {
+	name string
+	t    Translator
+
+	mu     sync.Mutex
+	health EngineHealth
+
+	// timedOut is the number of timed-out calls still running.
+	timedOut atomic.Int32
+}
+
+func (e *chainEngine) healthy(now time.Time, cooldown time.Duration) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.health.ConsecutiveFailures < maxConsecutiveFailures ||
+		now.Sub(e.health.LastFailure) >= cooldown
+}
+
+func (e *chainEngine) report(now time.Time, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err == nil {
+		e.health.Successes++
+		e.health.ConsecutiveFailures = 0
+		return
+	}
+	e.health.Failures++
+	e.health.ConsecutiveFailures++
+	e.health.LastError = err.Error()
+	e.health.LastFailure = now
+}
+
+var (
+	_ BatchTranslator      = (*Chain)(nil)
+	_ StructuredTranslator = (*Chain)(nil)
+)
+
+// Chain is a translator that tries its engines in order, and fails over
+// to the next engine on errors and timeouts. Engines that keep failing
+// are skipped for a cooldown period, unless all engines are unhealthy.
+type Chain struct {
+	engines  []*chainEngine
+	timeout  time.Duration
+	cooldown time.Duration
+	now      func() time.Time
+}
+
+type ChainOption func(*Chain)
+
+func WithChainTimeout(timeout time.Duration) ChainOption {
+	return func(c *Chain) {
+		c.timeout = timeout
+	}
+}
+
+func WithChainCooldown(cooldown time.Duration) ChainOption {
+	return func(c *Chain) {
+		c.cooldown = cooldown
+	}
+}
+
+func NewChain(opts ...ChainOption) *Chain {
+	c := &Chain{
+		timeout:  DefaultChainTimeout,
+		cooldown: DefaultChainCooldown,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Add appends an engine to the chain, name is used for health reports.
+func (c *Chain) Add(name string, t Translator) *Chain {
+	c.engines = append(c.engines, &chainEngine{
+		name:   strings.ToLower(name),
+		t:      t,
+		health: EngineHealth{Engine: strings.ToLower(name)},
+	})
+	return c
+}
+
+// Engines returns the engine names in order.
+func (c *Chain) Engines() []string {
+	names := make([]string, len(c.engines))
+	for i, e := range c.engines {
+		names[i] = e.name
+	}
+	return names
+}
+
+// Health returns the health of all engines in order.
+func (c *Chain) Health() []EngineHealth {
+	now := c.now()
+	health := make([]EngineHealth, len(c.engines))
+	for i, e := range c.engines {
+		e.mu.Lock()
+		health[i] = e.health
+		e.mu.Unlock()
+		health[i].Healthy = e.healthy(now, c.cooldown)
+	}
+	return health
+}
+
+func (c *Chain) Translate(text, from, to string) (string, error) {
+	return chainCall(c, func(t Translator) (string, error) {
+		return t.Translate(text, from, to)
+	})
+}
+
+func (c *Chain) TranslateBatch(texts []string, from, to string) ([]string, error) {
+	return chainCall(c, func(t Translator) ([]string, error) {
+		return TranslateBatch(t, texts, from, to)
+	})
+}
+
+// TranslateStructured translates v with the first engine that succeeds.
+// ErrStructuredUnsupported of an engine is returned as is, instead of
+// failing over to the next engine, so that callers fall back to texts
+// translated by the engines in order.
+func (c *Chain) TranslateStructured(v any, from, to string) error {
+	typ := reflect.TypeOf(v)
+	if typ == nil || typ.Kind() != reflect.Pointer {
+		return fmt.Errorf("translate: %T is not a pointer", v)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	result, err := chainCall(c, func(t Translator) (reflect.Value, error) {
+		// each engine translates its own copy, since a timed-out
+		// call keeps running and modifying it in the background.
+		cp := reflect.New(typ.Elem())
+		if err := json.Unmarshal(data, cp.Interface()); err != nil {
+			return cp, err
+		}
+		return cp, TranslateStructured(t, cp.Interface(), from, to)
+	})
+	if err != nil {
+		return err
+	}
+	reflect.ValueOf(v).Elem().Set(result.Elem())
+	return nil
+}
+
+func chainCall[T any](c *Chain, fn func(Translator) (T, error)) (result T, err error) {
+	if len(c.engines) == 0 {
+		return result, ErrTranslator
+	}
+	var (
+		now     = c.now()
+		healthy []*chainEngine
+	)
+	for _, e := range c.engines {
+		if e.healthy(now, c.cooldown) {
+			healthy = append(healthy, e)
+		}
+	}
+	if len(healthy) == 0 {
+		healthy = c.engines // try them anyway.
+	}
+	var errs []error
+	for _, e := range healthy {
+		result, err = callWithTimeout(c.timeout, &e.timedOut, func() (T, error) { return fn(e.t) })
+		if errors.Is(err, ErrStructuredUnsupported) {
+			return result, err // not a failure of the engine.
+		}
+		e.report(c.now(), err)
+		if err == nil {
+			return result, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", e.name, err))
+	}
+	return result, errors.Join(errs...)
+}
+
+// callWithTimeout returns ErrTimeout if fn doesn't return in time,
+// the result of fn is discarded then. Translators are not cancelable,
+// so a timed-out fn keeps running with its request in the background,
+// timedOut counts such calls and no more fn is started while it is at
+// maxTimedOutCalls.
+func callWithTimeout[T any](timeout time.Duration, timedOut *atomic.Int32, fn func() (T, error)) (T, error) {
+	if timeout <= 0 {
+		return fn()
+	}
+	var zero T
+	if timedOut.Load() >= maxTimedOutCalls {
+		return zero, fmt.Errorf("%w: too many timed-out calls in flight", ErrTimeout)
+	}
+	type result struct {
+		v   T
+		err error
+	}
+	const (
+		running int32 = iota
+		returned
+		abandoned
+	)
+	var (
+		state atomic.Int32
+		ch    = make(chan result, 1)
+	)
+	go func() {
+		v, err := fn()
+		ch <- result{v, err}
+		if !state.CompareAndSwap(running, returned) {
+			timedOut.Add(-1)
+		}
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case r := <-ch:
+		return r.v, r.err
+	case <-timer.C:
+		if !state.CompareAndSwap(running, abandoned) {
+			// fn returned just in time.
+			r := <-ch
+			return r.v, r.err
+		}
+		timedOut.Add(1)
+		return zero, ErrTimeout
+	}
+}
@@ -0,0 +1,158 @@
+package translate
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sleepyTranslator struct {
+	countingTranslator
+	d time.Duration
+}
+
+func (t *sleepyTranslator) Translate(text, from, to string) (string, error) {
+	time.Sleep(t.d)
+	return t.countingTranslator.Translate(text, from, to)
+}
+
+func TestChainFailover(t *testing.T) {
+	var (
+		failing = &countingTranslator{err: errors.New("quota exceeded")}
+		slow    = &sleepyTranslator{d: time.Second}
+		good    = &countingTranslator{}
+	)
+	chain := NewChain(WithChainTimeout(50*time.Millisecond)).
+		Add("DeepL", failing).
+		Add("openai", slow).
+		Add("googlefree", good)
+	assert.Equal(t, []string{"deepl", "openai", "googlefree"}, chain.Engines())
+
+	result, err := chain.Translate("hello", "en", "ja")
+	require.NoError(t, err)
+	assert.Equal(t, "ja:HELLO", result)
+
+	results, err := TranslateBatch(chain, []string{"a", "b"}, "en", "ja")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ja:A", "ja:B"}, results)
+
+	health := chain.Health()
+	assert.Equal(t, uint64(2), health[0].Failures)
+	assert.Equal(t, "quota exceeded", health[0].LastError)
+	assert.Equal(t, uint64(2), health[1].Failures)
+	assert.Equal(t, ErrTimeout.Error(), health[1].LastError)
+	assert.Equal(t, uint64(2), health[2].Successes)
+	assert.True(t, health[2].Healthy)
+}
+
+func TestChainHealth(t *testing.T) {
+	now := time.Now()
+	var (
+		failing = &countingTranslator{err: errors.New("rate limited")}
+		good    = &countingTranslator{}
+	)
+	chain := NewChain(WithChainCooldown(time.Minute)).
+		Add("deepl", failing).
+		Add("google", good)
+	chain.now = func() time.Time { return now }
+
+	for i := 0; i < maxConsecutiveFailures; i++ {
+		_, err := chain.Translate("hello", "en", "ja")
+		require.NoError(t, err)
+	}
+	assert.False(t, chain.Health()[0].Healthy)
+
+	// unhealthy engine is skipped.
+	_, _ = chain.Translate("hello", "en", "ja")
+	assert.Equal(t, maxConsecutiveFailures, failing.calls)
+
+	// and retried after the cooldown.
+	now = now.Add(time.Minute)
+	failing.err = nil
+	_, _ = chain.Translate("hello", "en", "ja")
+	assert.Equal(t, maxConsecutiveFailures+1, failing.calls)
+	assert.True(t, chain.Health()[0].Healthy)
+	assert.Zero(t, chain.Health()[0].ConsecutiveFailures)
+}
+
+func TestChainExhausted(t *testing.T) {
+	chain := NewChain().
+		Add("a", &countingTranslator{err: errors.New("error a")}).
+		Add("b", &countingTranslator{err: errors.New("error b")})
+	_, err := chain.Translate("hello", "en", "ja")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "a: error a")
+	assert.Contains(t, err.Error(), "b: error b")
+
+	_, err = NewChain().Translate("hello", "en", "ja")
+	assert.ErrorIs(t, err, ErrTranslator)
+}
+
+func TestChainStructured(t *testing.T) {
+	var (
+		failing = &structuredTranslator{structuredErr: errors.New("quota exceeded")}
+		good    = &structuredTranslator{}
+	)
+	chain := NewChain().
+		Add("openai", failing).
+		Add("gemini", good).
+		Add("google", &countingTranslator{})
+
+	obj := &movieInfoObject{Title: "title", Genres: []string{"drama"}}
+	require.NoError(t, TranslateStructured(chain, obj, "ja", "en"))
+	assert.Equal(t, &movieInfoObject{Title: "en:TITLE", Genres: []string{"en:DRAMA"}}, obj)
+	assert.Len(t, failing.objects, 1)
+	assert.Len(t, good.objects, 1)
+	assert.Equal(t, uint64(1), chain.Health()[0].Failures)
+
+	// engines without structured support are not failed over.
+	chain = NewChain().
+		Add("google", &countingTranslator{}).
+		Add("gemini", good)
+	obj = &movieInfoObject{Title: "title"}
+	assert.ErrorIs(t, TranslateStructured(chain, obj, "ja", "en"), ErrStructuredUnsupported)
+	assert.Equal(t, &movieInfoObject{Title: "title"}, obj)
+	assert.Len(t, good.objects, 1)
+	assert.Zero(t, chain.Health()[0].Failures)
+}
+
+type blockingTranslator struct {
+	release chan struct{}
+}
+
+func (t *blockingTranslator) Translate(text, _, _ string) (string, error) {
+	<-t.release
+	return text, nil
+}
+
+func TestChainTimedOutCalls(t *testing.T) {
+	blocking := &blockingTranslator{release: make(chan struct{})}
+	chain := NewChain(WithChainTimeout(10*time.Millisecond)).
+		Add("blocking", blocking)
+
+	for i := 0; i < maxTimedOutCalls; i++ {
+		_, err := chain.Translate("hello", "en", "ja")
+		assert.ErrorIs(t, err, ErrTimeout)
+		assert.NotContains(t, err.Error(), "too many timed-out calls")
+	}
+	assert.Equal(t, int32(maxTimedOutCalls), chain.engines[0].timedOut.Load())
+
+	// fails fast without starting more calls.
+	start := time.Now()
+	_, err := chain.Translate("hello", "en", "ja")
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.Contains(t, err.Error(), "too many timed-out calls")
+	assert.Less(t, time.Since(start), 10*time.Millisecond)
+
+	// timed-out calls are released once they return.
+	close(blocking.release)
+	assert.Eventually(t, func() bool {
+		return chain.engines[0].timedOut.Load() == 0
+	}, time.Second, time.Millisecond)
+	result, err := chain.Translate("hello", "en", "ja")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", result)
+}
@@ -8,7 +8,7 @@ import (
 	"github.com/metatube-community/metatube-sdk-go/translate"
 )
 
-var _ translate.Translator = (*DeepL)(nil)
+var _ translate.BatchTranslator = (*DeepL)(nil)
 
 type DeepL struct {
 	APIKey string `json:"deepl-api-key"`
@@ -16,13 +16,16 @@ type DeepL struct {
 	APIUrl string `json:"deepl-api-url"`
 }
 
-func (dpl *DeepL) Translate(q, source, target string) (result string, err error) {
+func (dpl *DeepL) newTranslator() *deeplx.Translator {
 	var opts []deeplx.TranslatorOption
 	if dpl.APIUrl != "" {
 		opts = append(opts, deeplx.WithBaseURL(dpl.APIUrl))
 	}
-	return deeplx.
-		NewTranslator(dpl.APIKey, opts...).
+	return deeplx.NewTranslator(dpl.APIKey, opts...)
+}
+
+func (dpl *DeepL) Translate(q, source, target string) (result string, err error) {
+	return dpl.newTranslator().
 		TranslateText(q,
 			parseToSupportedLanguage(target),
 			deeplx.WithSourceLang(
@@ -30,6 +33,28 @@ func (dpl *DeepL) Translate(q, source, target string) (result string, err error)
 		)
 }
 
+// TranslateBatch translates all texts in one request with the v2 API,
+// DeepLX v1 API only accepts a single text per request.
+func (dpl *DeepL) TranslateBatch(texts []string, source, target string) ([]string, error) {
+	if dpl.APIUrl != "" && !strings.HasSuffix(dpl.APIUrl, "/v2") {
+		return translate.TranslateEach(dpl, texts, source, target)
+	}
+	result, err := dpl.newTranslator().
+		TranslateTextV2(texts,
+			parseToSupportedLanguage(target),
+			deeplx.WithSourceLang(
+				parseToSupportedLanguage(source)),
+		)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]string, 0, len(result.Translations))
+	for _, translation := range result.Translations {
+		results = append(results, translation.Text)
+	}
+	return results, nil
+}
+
 func parseToSupportedLanguage(lang string) string {
 	lang = strings.ToUpper(lang)
 	switch lang {
@@ -0,0 +1,439 @@
+package translate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Kinds of glossary terms.
+const (
+	ActorTerm = "actor"
+	MakerTerm = "maker"
+	GenreTerm = "genre"
+)
+
+// Term is an entry of the glossary. A term takes effect only if it has
+// a translation, or it's protected to be kept unchanged.
+type Term struct {
+	Term string `json:"term"`
+	// From is the source language, empty for any language.
+	From string `json:"from,omitempty"`
+	// To is the target language, empty for any language.
+	To          string `json:"to,omitempty"`
+	Translation string `json:"translation,omitempty"`
+	Protected   bool   `json:"protected,omitempty"`
+	// Kind is an optional category of the term, e.g., actor.
+	Kind string `json:"kind,omitempty"`
+}
+
+// Effective reports whether the term affects translations.
+func (t *Term) Effective() bool {
+	return t.Term != "" && (t.Translation != "" || t.Protected)
+}
+
+// Result returns the substitution of the term.
+func (t *Term) Result() string {
+	if t.Translation != "" {
+		return t.Translation
+	}
+	return t.Term
+}
+
+// Normalized returns a copy of the term with normalized languages.
+func (t *Term) Normalized() *Term {
+	n := *t
+	n.From, n.To = normalizeLang(t.From), normalizeLang(t.To)
+	return &n
+}
+
+func (t *Term) key() termKey {
+	return termKey{term: t.Term, from: normalizeLang(t.From), to: normalizeLang(t.To)}
+}
+
+type termKey struct{ term, from, to string }
+
+func normalizeLang(lang string) string {
+	if lang = strings.ToLower(strings.TrimSpace(lang)); lang == "auto" {
+		return ""
+	}
+	return lang
+}
+
+// GlossaryStore persists glossary terms.
+type GlossaryStore interface {
+	Load() ([]*Term, error)
+	Save(terms ...*Term) error
+	Delete(term *Term) error
+}
+
+// Glossary is a term dictionary of language pairs, terms like actor
+// names, makers and genres are protected or substituted in translations.
+type Glossary struct {
+	mu    sync.RWMutex
+	store GlossaryStore
+	terms map[termKey]*Term
+	// all language pairs of each term.
+	byTerm map[string][]*Term
+	// effective terms of each language pair, indexed by the first rune.
+	matchers map[[2]string]termMatcher
+}
+
+// termMatcher maps the first rune to terms, longest first.
+type termMatcher map[rune][]*Term
+
+func NewGlossary() *Glossary {
+	return &Glossary{
+		terms:    make(map[termKey]*Term),
+		byTerm:   make(map[string][]*Term),
+		matchers: make(map[[2]string]termMatcher),
+	}
+}
+
+// Add adds the translation of the term in the target language.
+func (g *Glossary) Add(term, lang, translation string) {
+	g.AddTerms(&Term{Term: term, To: lang, Translation: translation})
+}
+
+// AddTerms adds or replaces terms in memory only.
+func (g *Glossary) AddTerms(terms ...*Term) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, t := range terms {
+		if t.Term == "" {
+			continue
+		}
+		t = t.Normalized()
+		g.remove(t.key())
+		g.terms[t.key()] = t
+		g.byTerm[t.Term] = append(g.byTerm[t.Term], t)
+	}
+	clear(g.matchers)
+}
+
+func (g *Glossary) remove(key termKey) {
+	old, ok := g.terms[key]
+	if !ok {
+		return
+	}
+	delete(g.terms, key)
+	terms := g.byTerm[key.term]
+	for i, t := range terms {
+		if t == old {
+			terms = append(terms[:i], terms[i+1:]...)
+			break
+		}
+	}
+	if len(terms) == 0 {
+		delete(g.byTerm, key.term)
+	} else {
+		g.byTerm[key.term] = terms
+	}
+}
+
+// Put adds or replaces terms, and saves them to the store if any.
+func (g *Glossary) Put(terms ...*Term) error {
+	if g.store != nil {
+		if err := g.store.Save(terms...); err != nil {
+			return err
+		}
+	}
+	g.AddTerms(terms...)
+	return nil
+}
+
+// Seed puts the terms that are not in the glossary yet, so that existing
+// terms are never overwritten, and returns the number of new terms.
+func (g *Glossary) Seed(terms ...*Term) (int, error) {
+	var added []*Term
+	g.mu.RLock()
+	for _, t := range terms {
+		if _, ok := g.terms[t.key()]; !ok && t.Term != "" {
+			added = append(added, t)
+		}
+	}
+	g.mu.RUnlock()
+	return len(added), g.Put(added...)
+}
+
+// Delete removes the term, and deletes it from the store if any.
+func (g *Glossary) Delete(term *Term) error {
+	if g.store != nil {
+		if err := g.store.Delete(term); err != nil {
+			return err
+		}
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.remove(term.key())
+	clear(g.matchers)
+	return nil
+}
+
+// Terms returns all terms sorted by term and languages.
+func (g *Glossary) Terms() []*Term {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	terms := make([]*Term, 0, len(g.terms))
+	for _, t := range g.terms {
+		t := *t
+		terms = append(terms, &t)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		a, b := terms[i].key(), terms[j].key()
+		if a.term != b.term {
+			return a.term < b.term
+		}
+		if a.from != b.from {
+			return a.from < b.from
+		}
+		return a.to < b.to
+	})
+	return terms
+}
+
+// Load loads terms from a JSON array of terms into memory.
+func (g *Glossary) Load(r io.Reader) error {
+	var terms []*Term
+	if err := json.NewDecoder(r).Decode(&terms); err != nil {
+		return fmt.Errorf("load glossary: %w", err)
+	}
+	g.AddTerms(terms...)
+	return nil
+}
+
+// LoadStore loads all terms from the store, and uses it to persist
+// the terms that are put or deleted later.
+func (g *Glossary) LoadStore(store GlossaryStore) error {
+	terms, err := store.Load()
+	if err != nil {
+		return err
+	}
+	g.AddTerms(terms...)
+	g.mu.Lock()
+	g.store = store
+	g.mu.Unlock()
+	return nil
+}
+
+// find returns the most specific effective term of the language pair,
+// terms of any source language match if the source language is unknown.
+func (g *Glossary) find(term, from, to string) (*Term, bool) {
+	from, to = normalizeLang(from), normalizeLang(to)
+	var (
+		found *Term
+		best  = -1
+	)
+	for _, t := range g.byTerm[term] {
+		if !t.Effective() ||
+			(t.To != "" && t.To != to) ||
+			(t.From != "" && from != "" && t.From != from) {
+			continue
+		}
+		var score int
+		if t.To != "" {
+			score += 2
+		}
+		if t.From != "" && t.From == from {
+			score++
+		}
+		if score > best {
+			found, best = t, score
+		}
+	}
+	return found, found != nil
+}
+
+// Lookup returns the substitution of the term from the source language
+// to the target language, from can be empty or auto for any language.
+func (g *Glossary) Lookup(term, from, to string) (string, bool) {
+	if g == nil {
+		return "", false
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if t, ok := g.find(term, from, to); ok {
+		return t.Result(), true
+	}
+	return "", false
+}
+
+// matcher returns the effective terms of the language pair.
+func (g *Glossary) matcher(from, to string) termMatcher {
+	pair := [2]string{normalizeLang(from), normalizeLang(to)}
+	g.mu.RLock()
+	m, ok := g.matchers[pair]
+	g.mu.RUnlock()
+	if ok {
+		return m
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	m = make(termMatcher)
+	for term := range g.byTerm {
+		if t, ok := g.find(term, pair[0], pair[1]); ok {
+			r, _ := utf8.DecodeRuneInString(t.Term)
+			m[r] = append(m[r], t)
+		}
+	}
+	for _, terms := range m {
+		sort.Slice(terms, func(i, j int) bool {
+			if len(terms[i].Term) != len(terms[j].Term) {
+				return len(terms[i].Term) > len(terms[j].Term)
+			}
+			return terms[i].Term < terms[j].Term
+		})
+	}
+	g.matchers[pair] = m
+	return m
+}
+
+// placeholderRegexp matches placeholders, tolerating spaces that
+// some translators insert.
+var placeholderRegexp = regexp.MustCompile(`__\s*T\s*(\d+)\s*__`)
+
+func placeholder(i int) string {
+	return "__T" + strconv.Itoa(i) + "__"
+}
+
+// Protect replaces the terms in the text with placeholders, and returns
+// the terms in the order of their placeholder indexes.
+func (g *Glossary) Protect(text, from, to string) (string, []*Term) {
+	var (
+		b     strings.Builder
+		terms []*Term
+		index = make(map[*Term]int)
+	)
+	matcher := g.matcher(from, to)
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		t := matchTermAt(matcher[r], text, i)
+		if t == nil {
+			b.WriteString(text[i : i+size])
+			i += size
+			continue
+		}
+		n, ok := index[t]
+		if !ok {
+			n = len(terms)
+			index[t] = n
+			terms = append(terms, t)
+		}
+		b.WriteString(placeholder(n))
+		i += len(t.Term)
+	}
+	return b.String(), terms
+}
+
+// Restore replaces the placeholders in the text with the substitutions.
+func (g *Glossary) Restore(text string, terms []*Term) string {
+	return placeholderRegexp.ReplaceAllStringFunc(text, func(s string) string {
+		n, err := strconv.Atoi(placeholderRegexp.FindStringSubmatch(s)[1])
+		if err != nil || n >= len(terms) {
+			return s
+		}
+		return terms[n].Result()
+	})
+}
+
+// matchTermAt returns the longest of terms at position i of the text. Terms
+// starting or ending with letters or digits must be on word boundaries,
+// so that short latin terms do not match part of a word.
+func matchTermAt(terms []*Term, text string, i int) *Term {
+	for _, t := range terms {
+		if !strings.HasPrefix(text[i:], t.Term) {
+			continue
+		}
+		if r, _ := utf8.DecodeRuneInString(t.Term); isWordRune(r) && i > 0 {
+			if prev, _ := utf8.DecodeLastRuneInString(text[:i]); isWordRune(prev) {
+				continue
+			}
+		}
+		if r, _ := utf8.DecodeLastRuneInString(t.Term); isWordRune(r) && i+len(t.Term) < len(text) {
+			if next, _ := utf8.DecodeRuneInString(text[i+len(t.Term):]); isWordRune(next) {
+				continue
+			}
+		}
+		return t
+	}
+	return nil
+}
+
+// isWordRune reports whether r is part of a space-delimited word, CJK
+// scripts are not delimited by spaces, so they are excluded.
+func isWordRune(r rune) bool {
+	if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+		return false
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Wrap returns a translator that protects or substitutes the terms of
+// the glossary before and after calling t.
+func (g *Glossary) Wrap(t Translator) Translator {
+	if _, ok := t.(*errorTranslator); ok || g == nil {
+		return t
+	}
+	return &glossaryTranslator{g: g, t: t}
+}
+
+var _ BatchTranslator = (*glossaryTranslator)(nil)
+
+type glossaryTranslator struct {
+	g *Glossary
+	t Translator
+}
+
+func (gt *glossaryTranslator) Translate(text, from, to string) (string, error) {
+	if result, ok := gt.g.Lookup(strings.TrimSpace(text), from, to); ok {
+		return result, nil // the whole text is a term.
+	}
+	protected, terms := gt.g.Protect(text, from, to)
+	if len(terms) == 0 {
+		return gt.t.Translate(text, from, to)
+	}
+	result, err := gt.t.Translate(protected, from, to)
+	if err != nil {
+		return "", err
+	}
+	return gt.g.Restore(result, terms), nil
+}
+
+// TranslateBatch translates the texts that are not terms in one batch.
+func (gt *glossaryTranslator) TranslateBatch(texts []string, from, to string) ([]string, error) {
+	var (
+		results   = make([]string, len(texts))
+		protected []string
+		terms     [][]*Term
+		indexes   []int
+	)
+	for i, text := range texts {
+		if result, ok := gt.g.Lookup(strings.TrimSpace(text), from, to); ok {
+			results[i] = result
+			continue
+		}
+		p, t := gt.g.Protect(text, from, to)
+		protected = append(protected, p)
+		terms = append(terms, t)
+		indexes = append(indexes, i)
+	}
+	if len(protected) == 0 {
+		return results, nil
+	}
+	translated, err := TranslateBatch(gt.t, protected, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for i, result := range translated {
+		results[indexes[i]] = gt.g.Restore(result, terms[i])
+	}
+	return results, nil
+}
@@ -0,0 +1,156 @@
+package translate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlossaryLookup(t *testing.T) {
+	g := NewGlossary()
+	g.AddTerms(
+		&Term{Term: "巨乳", To: "en", Translation: "Big Tits"},
+		&Term{Term: "巨乳", From: "ja", To: "zh-CN", Translation: "巨乳"},
+		&Term{Term: "S1", Protected: true},
+		&Term{Term: "ドラマ", Kind: GenreTerm}, // not effective.
+	)
+	for _, unit := range []struct {
+		term, from, to string
+		want           string
+		ok             bool
+	}{
+		{"巨乳", "auto", "EN", "Big Tits", true},
+		{"巨乳", "ja", "en", "Big Tits", true},
+		{"巨乳", "", "zh-cn", "巨乳", true},
+		{"巨乳", "ko", "zh-CN", "", false},
+		{"巨乳", "ja", "de", "", false},
+		{"S1", "ja", "de", "S1", true},
+		{"ドラマ", "ja", "en", "", false},
+		{"unknown", "ja", "en", "", false},
+	} {
+		got, ok := g.Lookup(unit.term, unit.from, unit.to)
+		assert.Equal(t, unit.ok, ok, unit.term)
+		assert.Equal(t, unit.want, got, unit.term)
+	}
+
+	require.NoError(t, g.Delete(&Term{Term: "S1"}))
+	_, ok := g.Lookup("S1", "", "en")
+	assert.False(t, ok)
+	assert.Len(t, g.Terms(), 3)
+}
+
+func TestGlossaryProtect(t *testing.T) {
+	g := NewGlossary()
+	g.AddTerms(
+		&Term{Term: "Ai", Protected: true},
+		&Term{Term: "三上悠亜", Protected: true},
+		&Term{Term: "三上", To: "en", Translation: "Mikami"},
+		&Term{Term: "中出し", To: "en", Translation: "Creampie"},
+	)
+	for _, unit := range []struct {
+		text  string
+		want  string
+		terms int
+	}{
+		{"三上悠亜の中出し", "__T0__の__T1__", 2},
+		{"三上と三上悠亜と三上", "__T0__と__T1__と__T0__", 2},
+		{"Ai and Air", "__T0__ and Air", 1},
+		{"Aiko", "Aiko", 0},
+	} {
+		got, terms := g.Protect(unit.text, "auto", "en")
+		assert.Equal(t, unit.want, got)
+		assert.Len(t, terms, unit.terms)
+	}
+
+	_, terms := g.Protect("三上悠亜の中出し", "auto", "en")
+	assert.Equal(t, "三上悠亜's Creampie", g.Restore("__T0__'s __ T1__", terms))
+}
+
+type upperTranslator struct {
+	texts []string
+}
+
+func (t *upperTranslator) Translate(text, _, _ string) (string, error) {
+	t.texts = append(t.texts, text)
+	return strings.ToUpper(text), nil
+}
+
+func TestGlossaryWrap(t *testing.T) {
+	g := NewGlossary()
+	g.AddTerms(
+		&Term{Term: "Yua Mikami", Protected: true},
+		&Term{Term: "drama", To: "ja", Translation: "ドラマ"},
+	)
+	tr := &upperTranslator{}
+	gt := g.Wrap(tr)
+
+	result, err := gt.Translate("a drama of Yua Mikami", "en", "ja")
+	require.NoError(t, err)
+	assert.Equal(t, "A ドラマ OF Yua Mikami", result)
+
+	// whole terms are not translated at all.
+	result, err = gt.Translate(" drama ", "en", "ja")
+	require.NoError(t, err)
+	assert.Equal(t, "ドラマ", result)
+
+	assert.Equal(t, []string{"a __T0__ of __T1__"}, tr.texts)
+}
+
+func TestGlossaryWrapStructured(t *testing.T) {
+	g := NewGlossary()
+	g.AddTerms(
+		&Term{Term: "Yua Mikami", Protected: true},
+		&Term{Term: "drama", To: "ja", Translation: "ドラマ"},
+	)
+	tr := &structuredTranslator{}
+	gt := g.Wrap(tr)
+
+	obj := &movieInfoObject{
+		Title:  "a drama of Yua Mikami",
+		Genres: []string{"school drama", "idol"},
+		Series: "Yua Mikami",
+	}
+	require.NoError(t, TranslateStructured(gt, obj, "en", "ja"))
+	assert.Equal(t, &movieInfoObject{
+		Title:  "ja:A ドラマ OF Yua Mikami",
+		Genres: []string{"ja:SCHOOL ドラマ", "ja:IDOL"},
+		Series: "ja:Yua Mikami",
+	}, obj)
+	assert.Equal(t, []movieInfoObject{{
+		Title:  "a __T0__ of __T1__",
+		Genres: []string{"school __T0__", "idol"},
+		Series: "__T0__",
+	}}, tr.objects)
+
+	// fields are restored on errors.
+	obj = &movieInfoObject{Title: "a drama"}
+	err := TranslateStructured(g.Wrap(&countingTranslator{}), obj, "en", "ja")
+	assert.ErrorIs(t, err, ErrStructuredUnsupported)
+	assert.Equal(t, &movieInfoObject{Title: "a drama"}, obj)
+}
+
+func TestGlossaryLoad(t *testing.T) {
+	g := NewGlossary()
+	require.NoError(t, g.Load(strings.NewReader(
+		`[{"term":"S1","protected":true},{"term":"中出し","to":"en","translation":"Creampie"}]`)))
+	got, ok := g.Lookup("中出し", "ja", "en")
+	assert.True(t, ok)
+	assert.Equal(t, "Creampie", got)
+	assert.Error(t, g.Load(strings.NewReader(`{`)))
+}
+
+func TestGlossarySeed(t *testing.T) {
+	g := NewGlossary()
+	g.AddTerms(&Term{Term: "ドラマ", To: "en", Translation: "Drama", Kind: GenreTerm})
+	n, err := g.Seed(
+		&Term{Term: "ドラマ", To: "EN", Kind: GenreTerm},
+		&Term{Term: "S1", Protected: true, Kind: MakerTerm},
+		&Term{Term: ""},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	got, _ := g.Lookup("ドラマ", "", "en")
+	assert.Equal(t, "Drama", got)
+}
@@ -12,7 +12,7 @@ import (
 	"github.com/metatube-community/metatube-sdk-go/translate"
 )
 
-var _ translate.Translator = (*Google)(nil)
+var _ translate.BatchTranslator = (*Google)(nil)
 
 const googleTranslateAPI = "https://translation.googleapis.com/language/translate/v2"
 
@@ -22,6 +22,16 @@ type Google struct {
 }
 
 func (gl *Google) Translate(q, source, target string) (result string, err error) {
+	var results []string
+	if results, err = gl.TranslateBatch([]string{q}, source, target); err == nil {
+		result = results[0]
+	}
+	return
+}
+
+// TranslateBatch translates all texts in one request, the v2 API
+// accepts repeated q parameters and keeps the order of results.
+func (gl *Google) TranslateBatch(texts []string, source, target string) (results []string, err error) {
 	apiURL := googleTranslateAPI
 	if gl.APIUrl != "" {
 		apiURL = gl.APIUrl
@@ -30,8 +40,8 @@ func (gl *Google) Translate(q, source, target string) (result string, err error)
 	var resp *http.Response
 	if resp, err = fetch.Post(
 		apiURL,
-		fetch.WithJSONBody(map[string]string{
-			"q":      q,
+		fetch.WithJSONBody(map[string]any{
+			"q":      texts,
 			"source": parseToSupportedLanguage(source),
 			"target": parseToSupportedLanguage(target),
 			"format": "text",
@@ -53,12 +63,17 @@ func (gl *Google) Translate(q, source, target string) (result string, err error)
 			} `json:"translations"`
 		} `json:"data"`
 	}{}
-	if err = json.NewDecoder(resp.Body).Decode(&data); err == nil {
-		if data.Error != nil {
-			err = data.Error
-		} else {
-			result = data.Data.Translations[0].TranslatedText
-		}
+	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return
+	}
+	if data.Error != nil {
+		return nil, data.Error
+	}
+	if len(data.Data.Translations) != len(texts) {
+		return nil, translate.ErrBatchSize
+	}
+	for _, translation := range data.Data.Translations {
+		results = append(results, translation.TranslatedText)
 	}
 	return
 }
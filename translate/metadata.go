@@ -0,0 +1,297 @@
+package translate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+// Metadata fields that can be translated.
+const (
+	TitleField   = "title"
+	SummaryField = "summary"
+	GenresField  = "genres"
+	MakerField   = "maker"
+	LabelField   = "label"
+	SeriesField  = "series"
+	HobbyField   = "hobby"
+	SkillField   = "skill"
+	CommentField = "comment"
+)
+
+// DefaultMetadataFields is the list of fields translated by default.
+var DefaultMetadataFields = []string{
+	TitleField,
+	SummaryField,
+	GenresField,
+	MakerField,
+	LabelField,
+	SeriesField,
+	HobbyField,
+	SkillField,
+	CommentField,
+}
+
+type (
+	MovieInfoTranslation struct {
+		Lang    string   `json:"lang"`
+		Title   string   `json:"title,omitempty"`
+		Summary string   `json:"summary,omitempty"`
+		Genres  []string `json:"genres,omitempty"`
+		Maker   string   `json:"maker,omitempty"`
+		Label   string   `json:"label,omitempty"`
+		Series  string   `json:"series,omitempty"`
+	}
+	MovieSearchResultTranslation struct {
+		Lang  string `json:"lang"`
+		Title string `json:"title,omitempty"`
+	}
+	ActorInfoTranslation struct {
+		Lang    string `json:"lang"`
+		Summary string `json:"summary,omitempty"`
+		Hobby   string `json:"hobby,omitempty"`
+		Skill   string `json:"skill,omitempty"`
+	}
+	MovieReviewTranslation struct {
+		Lang    string `json:"lang"`
+		Title   string `json:"title,omitempty"`
+		Comment string `json:"comment,omitempty"`
+	}
+)
+
+// Metadata along with their translations.
+type (
+	TranslatedMovieInfo struct {
+		*model.MovieInfo
+		Translated *MovieInfoTranslation `json:"translated"`
+	}
+	TranslatedMovieSearchResult struct {
+		*model.MovieSearchResult
+		Translated *MovieSearchResultTranslation `json:"translated"`
+	}
+	TranslatedActorInfo struct {
+		*model.ActorInfo
+		Translated *ActorInfoTranslation `json:"translated"`
+	}
+	TranslatedMovieReview struct {
+		*model.MovieReviewDetail
+		Translated *MovieReviewTranslation `json:"translated"`
+	}
+)
+
+// MetadataTranslator translates the text fields of metadata, terms like
+// genres and makers are looked up in the glossary first.
+type MetadataTranslator struct {
+	translator Translator
+	glossary   *Glossary
+	fields     map[string]bool
+}
+
+// NewMetadataTranslator creates a MetadataTranslator that translates the
+// given fields, or DefaultMetadataFields if none, glossary can be nil.
+func NewMetadataTranslator(t Translator, glossary *Glossary, fields ...string) *MetadataTranslator {
+	if len(fields) == 0 {
+		fields = DefaultMetadataFields
+	}
+	mt := &MetadataTranslator{
+		translator: t,
+		glossary:   glossary,
+		fields:     make(map[string]bool, len(fields)),
+	}
+	for _, field := range fields {
+		mt.fields[strings.ToLower(strings.TrimSpace(field))] = true
+	}
+	return mt
+}
+
+// translateJob translates text into dst, term is looked up in the glossary.
+type translateJob struct {
+	text string
+	term bool
+	dst  *string
+}
+
+func (mt *MetadataTranslator) job(field, text string, dst *string) []translateJob {
+	if !mt.fields[field] || text == "" {
+		return nil
+	}
+	return []translateJob{{text: text, dst: dst}}
+}
+
+func (mt *MetadataTranslator) termJob(field, text string, dst *string) []translateJob {
+	jobs := mt.job(field, text, dst)
+	for i := range jobs {
+		jobs[i].term = true
+	}
+	return jobs
+}
+
+// run translates the texts of the jobs in one batch, terms found in the
+// glossary are filled in directly.
+func (mt *MetadataTranslator) run(to string, jobs ...[]translateJob) error {
+	var (
+		texts []string
+		dsts  []*string
+	)
+	for _, js := range jobs {
+		for _, j := range js {
+			if j.term {
+				if translation, ok := mt.glossary.Lookup(j.text, "", to); ok {
+					*j.dst = translation
+					continue
+				}
+			}
+			texts = append(texts, j.text)
+			dsts = append(dsts, j.dst)
+		}
+	}
+	if len(texts) == 0 {
+		return nil
+	}
+	results, err := TranslateBatch(mt.translator, texts, "auto", to)
+	if err != nil {
+		return err
+	}
+	for i, result := range results {
+		*dsts[i] = result
+	}
+	return nil
+}
+
+func (mt *MetadataTranslator) MovieInfo(info *model.MovieInfo, to string) (*TranslatedMovieInfo, error) {
+	t := &MovieInfoTranslation{Lang: to}
+	err := mt.movieInfoStructured(info, t, to)
+	// fall back to the fields one by one unless it's a request error.
+	if errors.Is(err, ErrStructuredUnsupported) || errors.Is(err, ErrStructuredResult) {
+		t = &MovieInfoTranslation{Lang: to}
+		err = mt.movieInfo(info, t, to)
+	} else if err == nil {
+		// maker is a name rather than a part of the text.
+		err = mt.run(to, mt.termJob(MakerField, info.Maker, &t.Maker))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &TranslatedMovieInfo{MovieInfo: info, Translated: t}, nil
+}
+
+func (mt *MetadataTranslator) movieInfo(info *model.MovieInfo, t *MovieInfoTranslation, to string) error {
+	var genres []translateJob
+	if mt.fields[GenresField] && len(info.Genres) > 0 {
+		t.Genres = make([]string, len(info.Genres))
+		for i, genre := range info.Genres {
+			genres = append(genres, mt.termJob(GenresField, genre, &t.Genres[i])...)
+		}
+	}
+	return mt.run(to,
+		mt.job(TitleField, info.Title, &t.Title),
+		mt.job(SummaryField, info.Summary, &t.Summary),
+		mt.termJob(MakerField, info.Maker, &t.Maker),
+		mt.termJob(LabelField, info.Label, &t.Label),
+		mt.job(SeriesField, info.Series, &t.Series),
+		genres,
+	)
+}
+
+// movieInfoObject is translated by structured translators in one call,
+// fields not to be translated are left empty.
+type movieInfoObject struct {
+	Title   string   `json:"title" description:"Movie title"`
+	Summary string   `json:"summary" description:"Plot summary"`
+	Genres  []string `json:"genres" description:"Genre names"`
+	Series  string   `json:"series" description:"Series name"`
+	Label   string   `json:"label" description:"Label name"`
+}
+
+// movieInfoStructured translates the fields of info in one structured
+// call, genres and label found in the glossary are excluded.
+func (mt *MetadataTranslator) movieInfoStructured(info *model.MovieInfo, t *MovieInfoTranslation, to string) error {
+	obj := &movieInfoObject{Genres: []string{}}
+	if mt.fields[TitleField] {
+		obj.Title = info.Title
+	}
+	if mt.fields[SummaryField] {
+		obj.Summary = info.Summary
+	}
+	if mt.fields[SeriesField] {
+		obj.Series = info.Series
+	}
+	if mt.fields[LabelField] && info.Label != "" {
+		if translation, ok := mt.glossary.Lookup(info.Label, "", to); ok {
+			t.Label = translation
+		} else {
+			obj.Label = info.Label
+		}
+	}
+	var indexes []int
+	if mt.fields[GenresField] && len(info.Genres) > 0 {
+		t.Genres = make([]string, len(info.Genres))
+		for i, genre := range info.Genres {
+			if translation, ok := mt.glossary.Lookup(genre, "", to); ok {
+				t.Genres[i] = translation
+				continue
+			}
+			obj.Genres = append(obj.Genres, genre)
+			indexes = append(indexes, i)
+		}
+	}
+
+	if err := TranslateStructured(mt.translator, obj, "auto", to); err != nil {
+		return err
+	}
+	if len(obj.Genres) != len(indexes) {
+		return fmt.Errorf("%w: expected %d genres, got %d", ErrStructuredResult, len(indexes), len(obj.Genres))
+	}
+
+	t.Title, t.Summary, t.Series = obj.Title, obj.Summary, obj.Series
+	if obj.Label != "" {
+		t.Label = obj.Label
+	}
+	for i, genre := range obj.Genres {
+		t.Genres[indexes[i]] = genre
+	}
+	return nil
+}
+
+func (mt *MetadataTranslator) MovieSearchResults(results []*model.MovieSearchResult, to string) ([]*TranslatedMovieSearchResult, error) {
+	var jobs []translateJob
+	translated := make([]*TranslatedMovieSearchResult, len(results))
+	for i, result := range results {
+		t := &MovieSearchResultTranslation{Lang: to}
+		jobs = append(jobs, mt.job(TitleField, result.Title, &t.Title)...)
+		translated[i] = &TranslatedMovieSearchResult{MovieSearchResult: result, Translated: t}
+	}
+	if err := mt.run(to, jobs); err != nil {
+		return nil, err
+	}
+	return translated, nil
+}
+
+func (mt *MetadataTranslator) ActorInfo(info *model.ActorInfo, to string) (*TranslatedActorInfo, error) {
+	t := &ActorInfoTranslation{Lang: to}
+	if err := mt.run(to,
+		mt.job(SummaryField, info.Summary, &t.Summary),
+		mt.job(HobbyField, info.Hobby, &t.Hobby),
+		mt.job(SkillField, info.Skill, &t.Skill),
+	); err != nil {
+		return nil, err
+	}
+	return &TranslatedActorInfo{ActorInfo: info, Translated: t}, nil
+}
+
+func (mt *MetadataTranslator) MovieReviews(reviews []*model.MovieReviewDetail, to string) ([]*TranslatedMovieReview, error) {
+	var jobs []translateJob
+	translated := make([]*TranslatedMovieReview, len(reviews))
+	for i, review := range reviews {
+		t := &MovieReviewTranslation{Lang: to}
+		jobs = append(jobs, mt.job(TitleField, review.Title, &t.Title)...)
+		jobs = append(jobs, mt.job(CommentField, review.Comment, &t.Comment)...)
+		translated[i] = &TranslatedMovieReview{MovieReviewDetail: review, Translated: t}
+	}
+	if err := mt.run(to, jobs); err != nil {
+		return nil, err
+	}
+	return translated, nil
+}
@@ -0,0 +1,154 @@
+package translate
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/model"
+)
+
+func TestMetadataTranslator(t *testing.T) {
+	glossary := NewGlossary()
+	glossary.Add("巨乳", "EN", "Big Tits")
+
+	tr := &countingTranslator{}
+	mt := NewMetadataTranslator(tr, glossary)
+
+	info := &model.MovieInfo{
+		Title:  "title",
+		Genres: []string{"巨乳", "drama"},
+		Maker:  "maker",
+	}
+	translated, err := mt.MovieInfo(info, "en")
+	require.NoError(t, err)
+	assert.Equal(t, info, translated.MovieInfo)
+	assert.Equal(t, &MovieInfoTranslation{
+		Lang:   "en",
+		Title:  "en:TITLE",
+		Genres: []string{"Big Tits", "en:DRAMA"},
+		Maker:  "en:MAKER",
+	}, translated.Translated)
+	// empty fields and glossary terms are not translated.
+	assert.Equal(t, 3, tr.calls)
+
+	// texts are translated in one batch if supported.
+	bt := &batchTranslator{}
+	translated, err = NewMetadataTranslator(bt, glossary).MovieInfo(info, "en")
+	require.NoError(t, err)
+	assert.Equal(t, &MovieInfoTranslation{
+		Lang:   "en",
+		Title:  "en:TITLE",
+		Genres: []string{"Big Tits", "en:DRAMA"},
+		Maker:  "en:MAKER",
+	}, translated.Translated)
+	assert.Equal(t, [][]string{{"title", "maker", "drama"}}, bt.batches)
+	assert.Zero(t, bt.calls)
+
+	reviews, err := NewMetadataTranslator(tr, nil, CommentField).
+		MovieReviews([]*model.MovieReviewDetail{
+			{Title: "a", Comment: "b"},
+			{Title: "c", Comment: "d"},
+		}, "ja")
+	require.NoError(t, err)
+	if assert.Len(t, reviews, 2) {
+		assert.Equal(t, &MovieReviewTranslation{Lang: "ja", Comment: "ja:B"}, reviews[0].Translated)
+		assert.Equal(t, &MovieReviewTranslation{Lang: "ja", Comment: "ja:D"}, reviews[1].Translated)
+	}
+}
+
+type structuredTranslator struct {
+	countingTranslator
+	objects []movieInfoObject
+	// returned by structured calls if set.
+	structuredErr error
+}
+
+func (t *structuredTranslator) TranslateStructured(v any, _, to string) error {
+	obj := v.(*movieInfoObject)
+	t.objects = append(t.objects, *obj)
+	t.objects[len(t.objects)-1].Genres = slices.Clone(obj.Genres)
+	if t.structuredErr != nil {
+		return t.structuredErr
+	}
+	upper := func(s string) string {
+		if s == "" {
+			return ""
+		}
+		return to + ":" + strings.ToUpper(s)
+	}
+	obj.Title, obj.Summary, obj.Series, obj.Label =
+		upper(obj.Title), upper(obj.Summary), upper(obj.Series), upper(obj.Label)
+	for i := range obj.Genres {
+		obj.Genres[i] = upper(obj.Genres[i])
+	}
+	return nil
+}
+
+func TestMetadataTranslatorStructured(t *testing.T) {
+	glossary := NewGlossary()
+	glossary.Add("巨乳", "EN", "Big Tits")
+
+	tr := &structuredTranslator{}
+	cache := NewCache(NewMemoryCacheStore(0, 0))
+	mt := NewMetadataTranslator(glossary.Wrap(cache.Wrap("test", tr)), glossary)
+
+	info := &model.MovieInfo{
+		Title:   "title",
+		Summary: "summary",
+		Genres:  []string{"巨乳", "drama", "school"},
+		Maker:   "maker",
+		Label:   "label",
+	}
+	for i := 0; i < 2; i++ {
+		translated, err := mt.MovieInfo(info, "en")
+		require.NoError(t, err)
+		assert.Equal(t, &MovieInfoTranslation{
+			Lang:    "en",
+			Title:   "en:TITLE",
+			Summary: "en:SUMMARY",
+			Genres:  []string{"Big Tits", "en:DRAMA", "en:SCHOOL"},
+			Maker:   "en:MAKER",
+			Label:   "en:LABEL",
+		}, translated.Translated)
+	}
+	// translated in one call and cached, except for the maker.
+	assert.Equal(t, []movieInfoObject{{
+		Title:   "title",
+		Summary: "summary",
+		Genres:  []string{"drama", "school"},
+		Label:   "label",
+	}}, tr.objects)
+	assert.Equal(t, 1, tr.calls)
+}
+
+func TestMetadataTranslatorStructuredFallback(t *testing.T) {
+	info := &model.MovieInfo{
+		Title:  "title",
+		Genres: []string{"drama"},
+	}
+	want := &MovieInfoTranslation{
+		Lang:   "en",
+		Title:  "en:TITLE",
+		Genres: []string{"en:DRAMA"},
+	}
+
+	// invalid results fall back to the fields one by one.
+	tr := &structuredTranslator{structuredErr: fmt.Errorf("%w: missing genres", ErrStructuredResult)}
+	translated, err := NewMetadataTranslator(tr, nil).MovieInfo(info, "en")
+	require.NoError(t, err)
+	assert.Equal(t, want, translated.Translated)
+	assert.Len(t, tr.objects, 1)
+	assert.Equal(t, 2, tr.calls)
+
+	// but request errors are returned.
+	tr = &structuredTranslator{structuredErr: errors.New("quota exceeded")}
+	_, err = NewMetadataTranslator(tr, nil).MovieInfo(info, "en")
+	assert.EqualError(t, err, "quota exceeded")
+	assert.Zero(t, tr.calls)
+}
@@ -1,12 +1,24 @@
 package openai
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	goopenai "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/jsonschema"
 	openai "github.com/xjasonlyu/openai-translator"
 
 	"github.com/metatube-community/metatube-sdk-go/translate"
 )
 
-var _ translate.Translator = (*OpenAI)(nil)
+var (
+	_ translate.BatchTranslator      = (*OpenAI)(nil)
+	_ translate.StructuredTranslator = (*OpenAI)(nil)
+	_ translate.Variant              = (*OpenAI)(nil)
+)
 
 const defaultSystemPrompt = `You are a professional translator for adult video content. Your sole task is to translate the user's input accurately and naturally. 
 Rules:
@@ -18,11 +30,22 @@ Rules:
 6. Do not add any explanations, notes, or comments under any circumstances.
 7. Only output the translation result, with no additional content.`
 
+// batchSystemPrompt is appended to the system prompt in batch mode.
+const batchSystemPrompt = `
+The input is a JSON array of texts. Translate each text separately and respond with a JSON object {"translations": [...]} that contains the translations in the same order and with the same number of items.`
+
+// structuredSystemPrompt is appended to the system prompt in structured mode.
+const structuredSystemPrompt = `
+The input is a JSON object. Translate the string values and respond with a JSON object of the same keys. Keep arrays in the same order and with the same number of items, keep empty strings empty, and use consistent terminology across all fields.`
+
 type OpenAI struct {
 	APIKey string `json:"openai-api-key"`
 	APIUrl string `json:"openai-api-url"`
 	Model  string `json:"openai-model"`
 	Prompt string `json:"openai-prompt"`
+	// Structured enables translating objects in one call with the JSON
+	// schema response format, which requires a compatible server.
+	Structured bool `json:"openai-structured"`
 }
 
 func (oa *OpenAI) Translate(q, source, target string) (result string, err error) {
@@ -35,13 +58,133 @@ func (oa *OpenAI) Translate(q, source, target string) (result string, err error)
 		TranslateText(q, target,
 			openai.WithModel(oa.Model),
 			openai.WithSourceLanguage(source),
-			openai.WithSystemPrompt(map[bool]string{
-				true:  oa.Prompt,
-				false: defaultSystemPrompt,
-			}[oa.Prompt != ""]),
+			openai.WithSystemPrompt(oa.systemPrompt()),
 		)
 }
 
+func (oa *OpenAI) systemPrompt() string {
+	if oa.Prompt != "" {
+		return oa.Prompt
+	}
+	return defaultSystemPrompt
+}
+
+// TranslateBatch translates all texts in one chat completion with
+// the JSON response format, and falls back to translating the texts
+// one by one if the model doesn't respond with the expected array.
+func (oa *OpenAI) TranslateBatch(texts []string, source, target string) ([]string, error) {
+	if len(texts) == 1 {
+		return translate.TranslateEach(oa, texts, source, target)
+	}
+	input, err := json.Marshal(texts)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := oa.complete(
+		&goopenai.ChatCompletionResponseFormat{
+			Type: goopenai.ChatCompletionResponseFormatTypeJSONObject,
+		},
+		batchSystemPrompt,
+		instruction("each text of the following JSON array", source, target),
+		string(input))
+	if err != nil {
+		return nil, err
+	}
+
+	var data struct {
+		Translations []string `json:"translations"`
+	}
+	if json.Unmarshal([]byte(content), &data) != nil ||
+		len(data.Translations) != len(texts) {
+		return translate.TranslateEach(oa, texts, source, target)
+	}
+	return data.Translations, nil
+}
+
+// TranslateStructured translates all string fields of v in one call, the
+// response is validated against the JSON schema generated from v.
+func (oa *OpenAI) TranslateStructured(v any, source, target string) error {
+	if !oa.Structured {
+		return translate.ErrStructuredUnsupported
+	}
+	schema, err := jsonschema.GenerateSchemaForType(v)
+	if err != nil {
+		return err
+	}
+	input, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	content, err := oa.complete(
+		&goopenai.ChatCompletionResponseFormat{
+			Type: goopenai.ChatCompletionResponseFormatTypeJSONSchema,
+			JSONSchema: &goopenai.ChatCompletionResponseFormatJSONSchema{
+				Name:   "translation",
+				Schema: schema,
+				Strict: true,
+			},
+		},
+		structuredSystemPrompt,
+		instruction("the following JSON object", source, target),
+		string(input))
+	if err != nil {
+		return err
+	}
+	if err = schema.Unmarshal(content, v); err != nil {
+		return fmt.Errorf("%w: %w", translate.ErrStructuredResult, err)
+	}
+	return nil
+}
+
+// complete requests a chat completion in the response format and returns
+// the content, extra is appended to the system prompt.
+func (oa *OpenAI) complete(format *goopenai.ChatCompletionResponseFormat, extra string, messages ...string) (string, error) {
+	config := goopenai.DefaultConfig(oa.APIKey)
+	if oa.APIUrl != "" {
+		config.BaseURL = oa.APIUrl
+	}
+	model := oa.Model
+	if model == "" {
+		model = openai.DefaultModel
+	}
+	req := goopenai.ChatCompletionRequest{
+		Model:          model,
+		Temperature:    openai.DefaultTemperature,
+		ResponseFormat: format,
+		Messages: []goopenai.ChatCompletionMessage{
+			{Role: goopenai.ChatMessageRoleSystem, Content: oa.systemPrompt() + extra},
+		},
+	}
+	for _, message := range messages {
+		req.Messages = append(req.Messages, goopenai.ChatCompletionMessage{
+			Role:    goopenai.ChatMessageRoleUser,
+			Content: message,
+		})
+	}
+	resp, err := goopenai.NewClientWithConfig(config).CreateChatCompletion(context.Background(), req)
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Choices) == 0 {
+		return "", errors.New("empty response choices")
+	}
+	return resp.Choices[0].Message.Content, nil
+}
+
+func instruction(what, source, target string) string {
+	if lang := openai.LookupLanguage(source); lang != "" && lang != "auto" {
+		return fmt.Sprintf("Please translate %s from %s to %s:", what, lang, openai.LookupLanguage(target))
+	}
+	return fmt.Sprintf("Please translate %s into %s:", what, openai.LookupLanguage(target))
+}
+
+// Variant distinguishes translations of different models and prompts.
+func (oa *OpenAI) Variant() string {
+	return strings.Join([]string{oa.APIUrl, oa.Model, oa.Prompt}, "\x00")
+}
+
 func init() {
 	translate.Register(&OpenAI{})
 }
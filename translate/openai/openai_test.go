@@ -1,11 +1,18 @@
 package openai
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/sashabaranov/go-openai"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/metatube-community/metatube-sdk-go/translate"
 )
 
 func TestOpenaiTranslate(t *testing.T) {
@@ -28,3 +35,109 @@ func TestOpenaiTranslate(t *testing.T) {
 		}
 	}
 }
+
+func newStubServer(t *testing.T, content func(req *openai.ChatCompletionRequest) string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := &openai.ChatCompletionRequest{}
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(req)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(openai.ChatCompletionResponse{
+			Choices: []openai.ChatCompletionChoice{
+				{Message: openai.ChatCompletionMessage{
+					Role:    openai.ChatMessageRoleAssistant,
+					Content: content(req),
+				}},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOpenaiTranslateBatch(t *testing.T) {
+	texts := []string{"a", "b", "c"}
+
+	var requests int
+	server := newStubServer(t, func(req *openai.ChatCompletionRequest) string {
+		requests++
+		assert.NotNil(t, req.ResponseFormat)
+		var input []string
+		assert.NoError(t, json.Unmarshal([]byte(req.Messages[len(req.Messages)-1].Content), &input))
+		for i := range input {
+			input[i] = strings.ToUpper(input[i])
+		}
+		data, _ := json.Marshal(map[string]any{"translations": input})
+		return string(data)
+	})
+
+	results, err := (&OpenAI{APIUrl: server.URL + "/v1"}).TranslateBatch(texts, "en", "ja")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A", "B", "C"}, results)
+	assert.Equal(t, 1, requests)
+}
+
+func TestOpenaiTranslateBatchFallback(t *testing.T) {
+	texts := []string{"a", "b", "c"}
+
+	var requests int
+	server := newStubServer(t, func(req *openai.ChatCompletionRequest) string {
+		requests++
+		if req.ResponseFormat != nil {
+			return `{"translations": ["A"]}` // mismatched results.
+		}
+		return strings.ToUpper(req.Messages[len(req.Messages)-1].Content)
+	})
+
+	results, err := (&OpenAI{APIUrl: server.URL + "/v1"}).TranslateBatch(texts, "en", "ja")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A", "B", "C"}, results)
+	assert.Equal(t, 4, requests)
+}
+
+type movieFields struct {
+	Title  string   `json:"title"`
+	Genres []string `json:"genres"`
+}
+
+func TestOpenaiTranslateStructured(t *testing.T) {
+	server := newStubServer(t, func(req *openai.ChatCompletionRequest) string {
+		if assert.NotNil(t, req.ResponseFormat) && assert.NotNil(t, req.ResponseFormat.JSONSchema) {
+			assert.Equal(t, openai.ChatCompletionResponseFormatTypeJSONSchema, req.ResponseFormat.Type)
+			assert.True(t, req.ResponseFormat.JSONSchema.Strict)
+		}
+		input := &movieFields{}
+		assert.NoError(t, json.Unmarshal([]byte(req.Messages[len(req.Messages)-1].Content), input))
+		input.Title = strings.ToUpper(input.Title)
+		for i := range input.Genres {
+			input.Genres[i] = strings.ToUpper(input.Genres[i])
+		}
+		data, _ := json.Marshal(input)
+		return string(data)
+	})
+
+	v := &movieFields{Title: "title", Genres: []string{"a", "b"}}
+	err := (&OpenAI{APIUrl: server.URL + "/v1", Structured: true}).TranslateStructured(v, "ja", "en")
+	require.NoError(t, err)
+	assert.Equal(t, &movieFields{Title: "TITLE", Genres: []string{"A", "B"}}, v)
+
+	// disabled by default.
+	err = (&OpenAI{APIUrl: server.URL + "/v1"}).TranslateStructured(v, "ja", "en")
+	assert.ErrorIs(t, err, translate.ErrStructuredUnsupported)
+}
+
+func TestOpenaiTranslateStructuredInvalid(t *testing.T) {
+	for _, content := range []string{
+		`not a json`,
+		`{"title": "TITLE"}`,                   // missing genres.
+		`{"title": 1, "genres": []}`,           // wrong type.
+		`{"title": "TITLE", "genres": [null]}`, // wrong item type.
+	} {
+		server := newStubServer(t, func(*openai.ChatCompletionRequest) string { return content })
+		v := &movieFields{Title: "title", Genres: []string{"a"}}
+		err := (&OpenAI{APIUrl: server.URL + "/v1", Structured: true}).TranslateStructured(v, "ja", "en")
+		assert.ErrorIs(t, err, translate.ErrStructuredResult, content)
+		assert.Equal(t, &movieFields{Title: "title", Genres: []string{"a"}}, v)
+	}
+}
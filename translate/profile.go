@@ -0,0 +1,71 @@
+package translate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Profile is a server-side translator configuration referenced by name,
+// so that clients don't have to supply credentials of the engine.
+type Profile struct {
+	Engine string `json:"engine"`
+	// Options are keyed by the json tags of the translator,
+	// e.g., deepl-api-key.
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// New creates the translator of the profile.
+func (p *Profile) New() Translator {
+	values := url.Values{}
+	for key, value := range p.Options {
+		values.Set(strings.ToLower(key), value)
+	}
+	return NewWithValues(p.Engine, values)
+}
+
+// LoadProfiles decodes profiles keyed by names from a JSON object, e.g.,
+// {"default": {"engine": "deepl", "options": {"deepl-api-key": "..."}}}.
+func LoadProfiles(r io.Reader) (map[string]*Profile, error) {
+	var profiles map[string]*Profile
+	if err := json.NewDecoder(r).Decode(&profiles); err != nil {
+		return nil, err
+	}
+	for name, profile := range profiles {
+		if profile == nil || profile.Engine == "" {
+			return nil, fmt.Errorf("translate: profile %s has no engine", name)
+		}
+	}
+	return profiles, nil
+}
+
+// OptionKeys returns the option keys of the translator of the name,
+// which are the json tags of its fields, e.g., deepl-api-key.
+func OptionKeys(name string) []string {
+	f := match(name)
+	if f.new == nil {
+		return nil
+	}
+	typ := reflect.TypeOf(f.new()).Elem()
+	keys := make([]string, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		if tag, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ","); tag != "" && tag != "-" {
+			keys = append(keys, tag)
+		}
+	}
+	return keys
+}
+
+// HasOptions reports whether values contain any option of the
+// translator of the name.
+func HasOptions(name string, values url.Values) bool {
+	for _, key := range OptionKeys(name) {
+		if values.Has(key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package translate
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type profileTranslator struct {
+	APIKey string `json:"profile-api-key"`
+	Model  string `json:"profile-model,omitempty"`
+	Ignore string `json:"-"`
+	Plain  string
+}
+
+func (t *profileTranslator) Translate(text, _, _ string) (string, error) {
+	return t.Model + ":" + text, nil
+}
+
+func init() {
+	Register(&profileTranslator{})
+}
+
+func TestLoadProfiles(t *testing.T) {
+	profiles, err := LoadProfiles(strings.NewReader(`{
+		"default": {"engine": "profileTranslator", "options": {"Profile-API-Key": "key", "profile-model": "m"}}
+	}`))
+	require.NoError(t, err)
+	require.Contains(t, profiles, "default")
+
+	tr, ok := profiles["default"].New().(*profileTranslator)
+	require.True(t, ok)
+	assert.Equal(t, "key", tr.APIKey)
+	assert.Equal(t, "m", tr.Model)
+
+	_, err = LoadProfiles(strings.NewReader(`{"default": {"options": {}}}`))
+	assert.Error(t, err)
+}
+
+func TestHasOptions(t *testing.T) {
+	assert.Equal(t, []string{"profile-api-key", "profile-model"}, OptionKeys("profiletranslator"))
+	assert.Nil(t, OptionKeys("unknown"))
+
+	assert.True(t, HasOptions("profileTranslator", url.Values{"profile-api-key": {"key"}}))
+	assert.False(t, HasOptions("profileTranslator", url.Values{"q": {"text"}}))
+}
@@ -0,0 +1,119 @@
+package translate
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+var (
+	ErrStructuredUnsupported = errors.New("translate: structured translation unsupported")
+	// ErrStructuredResult is returned if the result of a structured
+	// translation is invalid, e.g., not conforming to the schema.
+	ErrStructuredResult = errors.New("translate: invalid structured translation")
+)
+
+// StructuredTranslator is optionally implemented by translators that can
+// translate all string fields of an object in one call, so that the
+// terminology is consistent across fields.
+type StructuredTranslator interface {
+	// TranslateStructured translates the string fields of v, a pointer
+	// to struct, in place. The result must conform to the JSON schema
+	// of the type of v.
+	TranslateStructured(v any, from, to string) error
+}
+
+// TranslateStructured translates v in place if t is a StructuredTranslator,
+// otherwise ErrStructuredUnsupported is returned.
+func TranslateStructured(t Translator, v any, from, to string) error {
+	st, ok := t.(StructuredTranslator)
+	if !ok {
+		return ErrStructuredUnsupported
+	}
+	return st.TranslateStructured(v, from, to)
+}
+
+var _ StructuredTranslator = (*cachedTranslator)(nil)
+
+// TranslateStructured caches the whole object by its JSON encoding.
+func (ct *cachedTranslator) TranslateStructured(v any, from, to string) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	key := ct.key(string(data), from, to)
+	// objects of different types are not interchangeable.
+	key.Variant += "\x00" + reflect.TypeOf(v).String()
+	if result, ok := ct.cache.store.Get(key); ok &&
+		json.Unmarshal([]byte(result), v) == nil {
+		ct.cache.hits.Add(1)
+		return nil
+	}
+	ct.cache.misses.Add(1)
+	if err = TranslateStructured(ct.t, v, from, to); err != nil {
+		return err
+	}
+	if data, err = json.Marshal(v); err == nil {
+		_ = ct.cache.store.Set(key, string(data)) // ignore error
+	}
+	return nil
+}
+
+var _ StructuredTranslator = (*glossaryTranslator)(nil)
+
+// TranslateStructured protects the terms in the string fields of v like
+// Translate, the fields are restored to the original on errors.
+func (gt *glossaryTranslator) TranslateStructured(v any, from, to string) error {
+	var (
+		fields    = stringValues(reflect.ValueOf(v))
+		originals = make([]string, len(fields))
+		terms     = make([][]*Term, len(fields))
+	)
+	for i, field := range fields {
+		originals[i] = field.String()
+		var protected string
+		protected, terms[i] = gt.g.Protect(originals[i], from, to)
+		field.SetString(protected)
+	}
+	if err := TranslateStructured(gt.t, v, from, to); err != nil {
+		for i, field := range stringValues(reflect.ValueOf(v)) {
+			if i < len(originals) {
+				field.SetString(originals[i])
+			}
+		}
+		return err
+	}
+	for i, field := range stringValues(reflect.ValueOf(v)) {
+		if i < len(terms) && len(terms[i]) > 0 {
+			field.SetString(gt.g.Restore(field.String(), terms[i]))
+		}
+	}
+	return nil
+}
+
+// stringValues returns the settable strings in v in order, including the
+// strings of exported fields and slice elements.
+func stringValues(v reflect.Value) []reflect.Value {
+	var values []reflect.Value
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			values = stringValues(v.Elem())
+		}
+	case reflect.String:
+		if v.CanSet() {
+			values = append(values, v)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				values = append(values, stringValues(v.Field(i))...)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, stringValues(v.Index(i))...)
+		}
+	}
+	return values
+}
@@ -2,10 +2,12 @@ package translate
 
 import (
 	"errors"
+	"net/url"
 	"reflect"
 	"strings"
 	"sync"
 
+	"github.com/gorilla/schema"
 	"go.uber.org/atomic"
 )
 
@@ -75,3 +77,18 @@ func New(name string, unmarshal func(any) error) Translator {
 	}
 	return t
 }
+
+var valuesDecoder = func() *schema.Decoder {
+	decoder := schema.NewDecoder()
+	decoder.SetAliasTag("json")
+	decoder.IgnoreUnknownKeys(true)
+	return decoder
+}()
+
+// NewWithValues is like New, but decodes the translator options from url
+// values keyed by the json tags of the translator, e.g., deepl-api-key.
+func NewWithValues(name string, values url.Values) Translator {
+	return New(name, func(v any) error {
+		return valuesDecoder.Decode(v, values)
+	})
+}